@@ -36,6 +36,7 @@ const (
 	FlowType_Shell       FlowType = 1
 	FlowType_DockerBuild FlowType = 2
 	FlowType_Lua         FlowType = 3
+	FlowType_Curl        FlowType = 4
 )
 
 // Enum value maps for FlowType.
@@ -45,12 +46,14 @@ var (
 		1: "Shell",
 		2: "DockerBuild",
 		3: "Lua",
+		4: "Curl",
 	}
 	FlowType_value = map[string]int32{
 		"SCM":         0,
 		"Shell":       1,
 		"DockerBuild": 2,
 		"Lua":         3,
+		"Curl":        4,
 	}
 )
 
@@ -81,6 +84,55 @@ func (FlowType) EnumDescriptor() ([]byte, []int) {
 	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{0}
 }
 
+// 可重试的错误类型
+type ErrorClass int32
+
+const (
+	// 网络错误，如连接被拒绝、连接重置、域名解析失败等
+	ErrorClass_NetworkError ErrorClass = 0
+	// 流程执行超时
+	ErrorClass_TimeoutError ErrorClass = 1
+)
+
+// Enum value maps for ErrorClass.
+var (
+	ErrorClass_name = map[int32]string{
+		0: "NetworkError",
+		1: "TimeoutError",
+	}
+	ErrorClass_value = map[string]int32{
+		"NetworkError": 0,
+		"TimeoutError": 1,
+	}
+)
+
+func (x ErrorClass) Enum() *ErrorClass {
+	p := new(ErrorClass)
+	*p = x
+	return p
+}
+
+func (x ErrorClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_pb_v1_pipeline_proto_enumTypes[1].Descriptor()
+}
+
+func (ErrorClass) Type() protoreflect.EnumType {
+	return &file_api_pb_v1_pipeline_proto_enumTypes[1]
+}
+
+func (x ErrorClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorClass.Descriptor instead.
+func (ErrorClass) EnumDescriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{1}
+}
+
 type VCSType int32
 
 const (
@@ -111,11 +163,11 @@ func (x VCSType) String() string {
 }
 
 func (VCSType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[1].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[2].Descriptor()
 }
 
 func (VCSType) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[1]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[2]
 }
 
 func (x VCSType) Number() protoreflect.EnumNumber {
@@ -124,7 +176,7 @@ func (x VCSType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use VCSType.Descriptor instead.
 func (VCSType) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{1}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{2}
 }
 
 // 凭证类型
@@ -171,11 +223,11 @@ func (x CreditType) String() string {
 }
 
 func (CreditType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[2].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[3].Descriptor()
 }
 
 func (CreditType) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[2]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[3]
 }
 
 func (x CreditType) Number() protoreflect.EnumNumber {
@@ -184,7 +236,64 @@ func (x CreditType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CreditType.Descriptor instead.
 func (CreditType) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{2}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{3}
+}
+
+// 代码检出的引用类型
+type RefType int32
+
+const (
+	// 分支，检出branch的最新提交
+	RefType_Branch RefType = 0
+	// tag
+	RefType_Tag RefType = 1
+	// 指定的commit sha，支持短sha
+	RefType_Commit RefType = 2
+	// pull request / merge request
+	RefType_PullRequest RefType = 3
+)
+
+// Enum value maps for RefType.
+var (
+	RefType_name = map[int32]string{
+		0: "Branch",
+		1: "Tag",
+		2: "Commit",
+		3: "PullRequest",
+	}
+	RefType_value = map[string]int32{
+		"Branch":      0,
+		"Tag":         1,
+		"Commit":      2,
+		"PullRequest": 3,
+	}
+)
+
+func (x RefType) Enum() *RefType {
+	p := new(RefType)
+	*p = x
+	return p
+}
+
+func (x RefType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RefType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_pb_v1_pipeline_proto_enumTypes[4].Descriptor()
+}
+
+func (RefType) Type() protoreflect.EnumType {
+	return &file_api_pb_v1_pipeline_proto_enumTypes[4]
+}
+
+func (x RefType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RefType.Descriptor instead.
+func (RefType) EnumDescriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{4}
 }
 
 type ImagePullPolicy int32
@@ -220,11 +329,11 @@ func (x ImagePullPolicy) String() string {
 }
 
 func (ImagePullPolicy) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[3].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[5].Descriptor()
 }
 
 func (ImagePullPolicy) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[3]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[5]
 }
 
 func (x ImagePullPolicy) Number() protoreflect.EnumNumber {
@@ -233,7 +342,7 @@ func (x ImagePullPolicy) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ImagePullPolicy.Descriptor instead.
 func (ImagePullPolicy) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{3}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{5}
 }
 
 type ConditionConnector int32
@@ -266,11 +375,11 @@ func (x ConditionConnector) String() string {
 }
 
 func (ConditionConnector) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[4].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[6].Descriptor()
 }
 
 func (ConditionConnector) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[4]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[6]
 }
 
 func (x ConditionConnector) Number() protoreflect.EnumNumber {
@@ -279,7 +388,7 @@ func (x ConditionConnector) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConditionConnector.Descriptor instead.
 func (ConditionConnector) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{4}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{6}
 }
 
 type Status int32
@@ -291,6 +400,14 @@ const (
 	Status_Succeed  Status = 3
 	Status_Failed   Status = 4
 	Status_Canceled Status = 5
+	// 流程执行条件不满足，未执行
+	Status_Skipped Status = 6
+	// 允许失败的流程执行失败
+	Status_FailedAllowed Status = 7
+	// 流水线执行成功，但有允许失败的流程执行失败
+	Status_SucceedWithWarnings Status = 8
+	// 执行超时
+	Status_TimedOut Status = 9
 )
 
 // Enum value maps for Status.
@@ -302,14 +419,22 @@ var (
 		3: "Succeed",
 		4: "Failed",
 		5: "Canceled",
+		6: "Skipped",
+		7: "FailedAllowed",
+		8: "SucceedWithWarnings",
+		9: "TimedOut",
 	}
 	Status_value = map[string]int32{
-		"Created":  0,
-		"Started":  1,
-		"Running":  2,
-		"Succeed":  3,
-		"Failed":   4,
-		"Canceled": 5,
+		"Created":             0,
+		"Started":             1,
+		"Running":             2,
+		"Succeed":             3,
+		"Failed":              4,
+		"Canceled":            5,
+		"Skipped":             6,
+		"FailedAllowed":       7,
+		"SucceedWithWarnings": 8,
+		"TimedOut":            9,
 	}
 )
 
@@ -324,11 +449,11 @@ func (x Status) String() string {
 }
 
 func (Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[5].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[7].Descriptor()
 }
 
 func (Status) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[5]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[7]
 }
 
 func (x Status) Number() protoreflect.EnumNumber {
@@ -337,7 +462,7 @@ func (x Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status.Descriptor instead.
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{5}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{7}
 }
 
 type CurlCfg_RequestType int32
@@ -376,11 +501,11 @@ func (x CurlCfg_RequestType) String() string {
 }
 
 func (CurlCfg_RequestType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[6].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[8].Descriptor()
 }
 
 func (CurlCfg_RequestType) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[6]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[8]
 }
 
 func (x CurlCfg_RequestType) Number() protoreflect.EnumNumber {
@@ -389,7 +514,7 @@ func (x CurlCfg_RequestType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CurlCfg_RequestType.Descriptor instead.
 func (CurlCfg_RequestType) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{7, 0}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type CurlCfg_ContentType int32
@@ -428,11 +553,11 @@ func (x CurlCfg_ContentType) String() string {
 }
 
 func (CurlCfg_ContentType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[7].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[9].Descriptor()
 }
 
 func (CurlCfg_ContentType) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[7]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[9]
 }
 
 func (x CurlCfg_ContentType) Number() protoreflect.EnumNumber {
@@ -441,7 +566,7 @@ func (x CurlCfg_ContentType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CurlCfg_ContentType.Descriptor instead.
 func (CurlCfg_ContentType) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{7, 1}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{12, 1}
 }
 
 type Condition_Compare int32
@@ -480,11 +605,11 @@ func (x Condition_Compare) String() string {
 }
 
 func (Condition_Compare) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_pb_v1_pipeline_proto_enumTypes[8].Descriptor()
+	return file_api_pb_v1_pipeline_proto_enumTypes[10].Descriptor()
 }
 
 func (Condition_Compare) Type() protoreflect.EnumType {
-	return &file_api_pb_v1_pipeline_proto_enumTypes[8]
+	return &file_api_pb_v1_pipeline_proto_enumTypes[10]
 }
 
 func (x Condition_Compare) Number() protoreflect.EnumNumber {
@@ -493,7 +618,7 @@ func (x Condition_Compare) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Condition_Compare.Descriptor instead.
 func (Condition_Compare) EnumDescriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{8, 0}
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{13, 0}
 }
 
 type Pipeline struct {
@@ -506,6 +631,23 @@ type Pipeline struct {
 	Title  string            `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	Flows  []*Flow           `protobuf:"bytes,4,rep,name=flows,proto3" json:"flows,omitempty"`
 	Params map[string]string `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 拉取、推送镜像及构建镜像时使用的镜像仓库凭证
+	RegistryCredits []*RegistryCredit `protobuf:"bytes,6,rep,name=registryCredits,proto3" json:"registryCredits,omitempty"`
+	// 全部流程执行结束后收集的构建产物，glob格式，相对于工作区，支持**匹配多级目录及!排除
+	Artifacts []string `protobuf:"bytes,7,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// 流程执行失败时不取消正在运行的其他流程，并继续执行不依赖失败流程的流程
+	KeepRunningOnFailure bool `protobuf:"varint,8,opt,name=keepRunningOnFailure,proto3" json:"keepRunningOnFailure,omitempty"`
+	// 按阶段分组的流程，与flows二选一，阶段之间按顺序执行
+	Stages []*Stage `protobuf:"bytes,9,rep,name=stages,proto3" json:"stages,omitempty"`
+	// 主流程全部成功后执行的流程
+	OnSuccess []*Flow `protobuf:"bytes,10,rep,name=onSuccess,proto3" json:"onSuccess,omitempty"`
+	// 主流程失败或被取消后执行的流程
+	OnFailure []*Flow `protobuf:"bytes,11,rep,name=onFailure,proto3" json:"onFailure,omitempty"`
+	// 主流程及onSuccess、onFailure执行结束后总是执行的流程
+	// 以上三类流程按顺序依次执行，执行时可通过CI_BUILD_STATUS及CI_BUILD_FAIL_REASON获取主流程的执行结果，执行失败不影响流水线状态
+	Finally []*Flow `protobuf:"bytes,12,rep,name=finally,proto3" json:"finally,omitempty"`
+	// 主流程的执行超时时间，如90m，为空时使用服务端默认值，不能超过服务端配置的上限
+	Timeout string `protobuf:"bytes,13,opt,name=timeout,proto3" json:"timeout,omitempty"`
 }
 
 func (x *Pipeline) Reset() {
@@ -575,134 +717,92 @@ func (x *Pipeline) GetParams() map[string]string {
 	return nil
 }
 
-type Flow struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Uid            string          `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Type           FlowType        `protobuf:"varint,2,opt,name=type,proto3,enum=trident.ci.v1.FlowType" json:"type,omitempty"`
-	ScmCfg         *ScmCfg         `protobuf:"bytes,3,opt,name=scmCfg,proto3" json:"scmCfg,omitempty"`
-	ShellCfg       *ShellCfg       `protobuf:"bytes,4,opt,name=shellCfg,proto3" json:"shellCfg,omitempty"`
-	DockerBuildCfg *DockerBuildCfg `protobuf:"bytes,5,opt,name=dockerBuildCfg,proto3" json:"dockerBuildCfg,omitempty"`
-	LuaCfg         *LuaCfg         `protobuf:"bytes,6,opt,name=luaCfg,proto3" json:"luaCfg,omitempty"`
-	NoEnvRender    bool            `protobuf:"varint,7,opt,name=noEnvRender,proto3" json:"noEnvRender,omitempty"`
-}
-
-func (x *Flow) Reset() {
-	*x = Flow{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Flow) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Flow) ProtoMessage() {}
-
-func (x *Flow) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Pipeline) GetRegistryCredits() []*RegistryCredit {
+	if x != nil {
+		return x.RegistryCredits
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Flow.ProtoReflect.Descriptor instead.
-func (*Flow) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{1}
+	return nil
 }
 
-func (x *Flow) GetUid() string {
+func (x *Pipeline) GetArtifacts() []string {
 	if x != nil {
-		return x.Uid
+		return x.Artifacts
 	}
-	return ""
+	return nil
 }
 
-func (x *Flow) GetType() FlowType {
+func (x *Pipeline) GetKeepRunningOnFailure() bool {
 	if x != nil {
-		return x.Type
+		return x.KeepRunningOnFailure
 	}
-	return FlowType_SCM
+	return false
 }
 
-func (x *Flow) GetScmCfg() *ScmCfg {
+func (x *Pipeline) GetStages() []*Stage {
 	if x != nil {
-		return x.ScmCfg
+		return x.Stages
 	}
 	return nil
 }
 
-func (x *Flow) GetShellCfg() *ShellCfg {
+func (x *Pipeline) GetOnSuccess() []*Flow {
 	if x != nil {
-		return x.ShellCfg
+		return x.OnSuccess
 	}
 	return nil
 }
 
-func (x *Flow) GetDockerBuildCfg() *DockerBuildCfg {
+func (x *Pipeline) GetOnFailure() []*Flow {
 	if x != nil {
-		return x.DockerBuildCfg
+		return x.OnFailure
 	}
 	return nil
 }
 
-func (x *Flow) GetLuaCfg() *LuaCfg {
+func (x *Pipeline) GetFinally() []*Flow {
 	if x != nil {
-		return x.LuaCfg
+		return x.Finally
 	}
 	return nil
 }
 
-func (x *Flow) GetNoEnvRender() bool {
+func (x *Pipeline) GetTimeout() string {
 	if x != nil {
-		return x.NoEnvRender
+		return x.Timeout
 	}
-	return false
+	return ""
 }
 
-// 凭证模型
-type Credit struct {
+// 阶段，前一阶段的流程全部成功后才会执行下一阶段
+type Stage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// 凭证类型
-	Type CreditType `protobuf:"varint,1,opt,name=type,proto3,enum=trident.ci.v1.CreditType" json:"type,omitempty"`
-	// 用户名
-	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	// 密码,当type=0或type=1（作为私钥的passphrase）时有效
-	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	// ssh私钥,当type=1时有效
-	PrivateKey string `protobuf:"bytes,4,opt,name=privateKey,proto3" json:"privateKey,omitempty"` // 鉴权token,当type=2｜3时有效
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 阶段内的流程不支持dependsOn
+	Flows []*Flow `protobuf:"bytes,2,rep,name=flows,proto3" json:"flows,omitempty"`
+	// 阶段内的流程是否并行执行，否则按顺序依次执行
+	Parallel bool `protobuf:"varint,3,opt,name=parallel,proto3" json:"parallel,omitempty"`
 }
 
-func (x *Credit) Reset() {
-	*x = Credit{}
+func (x *Stage) Reset() {
+	*x = Stage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[2]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Credit) String() string {
+func (x *Stage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Credit) ProtoMessage() {}
+func (*Stage) ProtoMessage() {}
 
-func (x *Credit) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[2]
+func (x *Stage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,67 +813,63 @@ func (x *Credit) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Credit.ProtoReflect.Descriptor instead.
-func (*Credit) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *Credit) GetType() CreditType {
-	if x != nil {
-		return x.Type
-	}
-	return CreditType_NoCredit
+// Deprecated: Use Stage.ProtoReflect.Descriptor instead.
+func (*Stage) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Credit) GetUsername() string {
+func (x *Stage) GetName() string {
 	if x != nil {
-		return x.Username
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Credit) GetPassword() string {
+func (x *Stage) GetFlows() []*Flow {
 	if x != nil {
-		return x.Password
+		return x.Flows
 	}
-	return ""
+	return nil
 }
 
-func (x *Credit) GetPrivateKey() string {
+func (x *Stage) GetParallel() bool {
 	if x != nil {
-		return x.PrivateKey
+		return x.Parallel
 	}
-	return ""
+	return false
 }
 
-type ScmCfg struct {
+// 镜像仓库凭证
+type RegistryCredit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VcsType VCSType `protobuf:"varint,1,opt,name=vcsType,proto3,enum=trident.ci.v1.VCSType" json:"vcsType,omitempty"`
-	Address string  `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	Branch  string  `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
-	Credit  *Credit `protobuf:"bytes,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	// 服务端凭证库中的凭证名，设置时忽略其余字段
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 仓库地址，如harbor.example.com，docker hub为docker.io
+	Server   string `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-func (x *ScmCfg) Reset() {
-	*x = ScmCfg{}
+func (x *RegistryCredit) Reset() {
+	*x = RegistryCredit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[3]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ScmCfg) String() string {
+func (x *RegistryCredit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScmCfg) ProtoMessage() {}
+func (*RegistryCredit) ProtoMessage() {}
 
-func (x *ScmCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[3]
+func (x *RegistryCredit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,67 +880,86 @@ func (x *ScmCfg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScmCfg.ProtoReflect.Descriptor instead.
-func (*ScmCfg) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RegistryCredit.ProtoReflect.Descriptor instead.
+func (*RegistryCredit) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ScmCfg) GetVcsType() VCSType {
+func (x *RegistryCredit) GetName() string {
 	if x != nil {
-		return x.VcsType
+		return x.Name
 	}
-	return VCSType_Git
+	return ""
 }
 
-func (x *ScmCfg) GetAddress() string {
+func (x *RegistryCredit) GetServer() string {
 	if x != nil {
-		return x.Address
+		return x.Server
 	}
 	return ""
 }
 
-func (x *ScmCfg) GetBranch() string {
+func (x *RegistryCredit) GetUsername() string {
 	if x != nil {
-		return x.Branch
+		return x.Username
 	}
 	return ""
 }
 
-func (x *ScmCfg) GetCredit() *Credit {
+func (x *RegistryCredit) GetPassword() string {
 	if x != nil {
-		return x.Credit
+		return x.Password
 	}
-	return nil
+	return ""
 }
 
-type ShellCfg struct {
+type Flow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cmd             string          `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
-	WithDocker      bool            `protobuf:"varint,2,opt,name=withDocker,proto3" json:"withDocker,omitempty"`
-	DockerImage     string          `protobuf:"bytes,3,opt,name=dockerImage,proto3" json:"dockerImage,omitempty"`
-	ImagePullPolicy ImagePullPolicy `protobuf:"varint,4,opt,name=imagePullPolicy,proto3,enum=trident.ci.v1.ImagePullPolicy" json:"imagePullPolicy,omitempty"`
+	Uid            string          `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Type           FlowType        `protobuf:"varint,2,opt,name=type,proto3,enum=trident.ci.v1.FlowType" json:"type,omitempty"`
+	ScmCfg         *ScmCfg         `protobuf:"bytes,3,opt,name=scmCfg,proto3" json:"scmCfg,omitempty"`
+	ShellCfg       *ShellCfg       `protobuf:"bytes,4,opt,name=shellCfg,proto3" json:"shellCfg,omitempty"`
+	DockerBuildCfg *DockerBuildCfg `protobuf:"bytes,5,opt,name=dockerBuildCfg,proto3" json:"dockerBuildCfg,omitempty"`
+	LuaCfg         *LuaCfg         `protobuf:"bytes,6,opt,name=luaCfg,proto3" json:"luaCfg,omitempty"`
+	NoEnvRender    bool            `protobuf:"varint,7,opt,name=noEnvRender,proto3" json:"noEnvRender,omitempty"`
+	// 流程执行结束后收集的构建产物，格式同Pipeline.artifacts
+	Artifacts []string `protobuf:"bytes,8,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// 依赖的流程uid，依赖的流程全部成功后才会执行，没有依赖关系的流程并行执行
+	// 流水线中没有任何流程声明依赖时，全部流程按顺序依次执行
+	DependsOn []string `protobuf:"bytes,9,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	// 执行条件，执行前根据环境变量判断，不满足时跳过流程
+	Conditions []*Condition `protobuf:"bytes,10,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// 多个条件之间的连接方式
+	ConditionConnector ConditionConnector `protobuf:"varint,11,opt,name=conditionConnector,proto3,enum=trident.ci.v1.ConditionConnector" json:"conditionConnector,omitempty"`
+	CurlCfg            *CurlCfg           `protobuf:"bytes,12,opt,name=curlCfg,proto3" json:"curlCfg,omitempty"`
+	// 失败重试策略，为空时不重试
+	Retry *RetryCfg `protobuf:"bytes,13,opt,name=retry,proto3" json:"retry,omitempty"`
+	// 流程失败时不影响流水线结果，标记为FailedAllowed，依赖它的流程继续执行
+	ContinueOnError bool `protobuf:"varint,14,opt,name=continueOnError,proto3" json:"continueOnError,omitempty"`
+	// 单次执行的超时时间，如30m，为空时使用服务端默认值，不能超过服务端配置的上限
+	Timeout string `protobuf:"bytes,15,opt,name=timeout,proto3" json:"timeout,omitempty"`
 }
 
-func (x *ShellCfg) Reset() {
-	*x = ShellCfg{}
+func (x *Flow) Reset() {
+	*x = Flow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[4]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShellCfg) String() string {
+func (x *Flow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShellCfg) ProtoMessage() {}
+func (*Flow) ProtoMessage() {}
 
-func (x *ShellCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[4]
+func (x *Flow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -855,136 +970,150 @@ func (x *ShellCfg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShellCfg.ProtoReflect.Descriptor instead.
-func (*ShellCfg) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Flow.ProtoReflect.Descriptor instead.
+func (*Flow) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ShellCfg) GetCmd() string {
+func (x *Flow) GetUid() string {
 	if x != nil {
-		return x.Cmd
+		return x.Uid
 	}
 	return ""
 }
 
-func (x *ShellCfg) GetWithDocker() bool {
+func (x *Flow) GetType() FlowType {
 	if x != nil {
-		return x.WithDocker
+		return x.Type
 	}
-	return false
+	return FlowType_SCM
 }
 
-func (x *ShellCfg) GetDockerImage() string {
+func (x *Flow) GetScmCfg() *ScmCfg {
 	if x != nil {
-		return x.DockerImage
+		return x.ScmCfg
 	}
-	return ""
+	return nil
 }
 
-func (x *ShellCfg) GetImagePullPolicy() ImagePullPolicy {
+func (x *Flow) GetShellCfg() *ShellCfg {
 	if x != nil {
-		return x.ImagePullPolicy
+		return x.ShellCfg
 	}
-	return ImagePullPolicy_IfNotPresent
+	return nil
 }
 
-type DockerBuildCfg struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	BaseImage      string `protobuf:"bytes,1,opt,name=baseImage,proto3" json:"baseImage,omitempty"`
-	TargetImage    string `protobuf:"bytes,2,opt,name=targetImage,proto3" json:"targetImage,omitempty"`
-	Dockerfile     string `protobuf:"bytes,3,opt,name=dockerfile,proto3" json:"dockerfile,omitempty"`
-	PushAfterBuild bool   `protobuf:"varint,4,opt,name=pushAfterBuild,proto3" json:"pushAfterBuild,omitempty"`
+func (x *Flow) GetDockerBuildCfg() *DockerBuildCfg {
+	if x != nil {
+		return x.DockerBuildCfg
+	}
+	return nil
 }
 
-func (x *DockerBuildCfg) Reset() {
-	*x = DockerBuildCfg{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Flow) GetLuaCfg() *LuaCfg {
+	if x != nil {
+		return x.LuaCfg
 	}
+	return nil
 }
 
-func (x *DockerBuildCfg) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Flow) GetNoEnvRender() bool {
+	if x != nil {
+		return x.NoEnvRender
+	}
+	return false
 }
 
-func (*DockerBuildCfg) ProtoMessage() {}
+func (x *Flow) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
 
-func (x *DockerBuildCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Flow) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use DockerBuildCfg.ProtoReflect.Descriptor instead.
-func (*DockerBuildCfg) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{5}
+func (x *Flow) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
 }
 
-func (x *DockerBuildCfg) GetBaseImage() string {
+func (x *Flow) GetConditionConnector() ConditionConnector {
 	if x != nil {
-		return x.BaseImage
+		return x.ConditionConnector
 	}
-	return ""
+	return ConditionConnector_And
 }
 
-func (x *DockerBuildCfg) GetTargetImage() string {
+func (x *Flow) GetCurlCfg() *CurlCfg {
 	if x != nil {
-		return x.TargetImage
+		return x.CurlCfg
 	}
-	return ""
+	return nil
 }
 
-func (x *DockerBuildCfg) GetDockerfile() string {
+func (x *Flow) GetRetry() *RetryCfg {
 	if x != nil {
-		return x.Dockerfile
+		return x.Retry
 	}
-	return ""
+	return nil
 }
 
-func (x *DockerBuildCfg) GetPushAfterBuild() bool {
+func (x *Flow) GetContinueOnError() bool {
 	if x != nil {
-		return x.PushAfterBuild
+		return x.ContinueOnError
 	}
 	return false
 }
 
-type LuaCfg struct {
+func (x *Flow) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+type RetryCfg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Script             string `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
-	ExitWhenErrorOccur bool   `protobuf:"varint,2,opt,name=exitWhenErrorOccur,proto3" json:"exitWhenErrorOccur,omitempty"`
+	// 失败后的最大重试次数
+	Retries int32 `protobuf:"varint,1,opt,name=retries,proto3" json:"retries,omitempty"`
+	// 首次重试前的等待时间，如5s，之后每次翻倍
+	Backoff string `protobuf:"bytes,2,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// 重试前的最大等待时间，为空时不限制
+	MaxBackoff string `protobuf:"bytes,3,opt,name=maxBackoff,proto3" json:"maxBackoff,omitempty"`
+	// 可重试的错误类型，与exitCodes均为空时任何错误都重试
+	ErrorClasses []ErrorClass `protobuf:"varint,4,rep,packed,name=errorClasses,proto3,enum=trident.ci.v1.ErrorClass" json:"errorClasses,omitempty"`
+	// 可重试的shell脚本退出码
+	ExitCodes []int32 `protobuf:"varint,5,rep,packed,name=exitCodes,proto3" json:"exitCodes,omitempty"`
 }
 
-func (x *LuaCfg) Reset() {
-	*x = LuaCfg{}
+func (x *RetryCfg) Reset() {
+	*x = RetryCfg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[6]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LuaCfg) String() string {
+func (x *RetryCfg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LuaCfg) ProtoMessage() {}
+func (*RetryCfg) ProtoMessage() {}
 
-func (x *LuaCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[6]
+func (x *RetryCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,56 +1124,79 @@ func (x *LuaCfg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LuaCfg.ProtoReflect.Descriptor instead.
-func (*LuaCfg) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RetryCfg.ProtoReflect.Descriptor instead.
+func (*RetryCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *LuaCfg) GetScript() string {
+func (x *RetryCfg) GetRetries() int32 {
 	if x != nil {
-		return x.Script
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *RetryCfg) GetBackoff() string {
+	if x != nil {
+		return x.Backoff
 	}
 	return ""
 }
 
-func (x *LuaCfg) GetExitWhenErrorOccur() bool {
+func (x *RetryCfg) GetMaxBackoff() string {
 	if x != nil {
-		return x.ExitWhenErrorOccur
+		return x.MaxBackoff
 	}
-	return false
+	return ""
 }
 
-type CurlCfg struct {
+func (x *RetryCfg) GetErrorClasses() []ErrorClass {
+	if x != nil {
+		return x.ErrorClasses
+	}
+	return nil
+}
+
+func (x *RetryCfg) GetExitCodes() []int32 {
+	if x != nil {
+		return x.ExitCodes
+	}
+	return nil
+}
+
+// 凭证模型
+type Credit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url             string              `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	PostData        string              `protobuf:"bytes,2,opt,name=postData,proto3" json:"postData,omitempty"`
-	ReqType         CurlCfg_RequestType `protobuf:"varint,3,opt,name=reqType,proto3,enum=trident.ci.v1.CurlCfg_RequestType" json:"reqType,omitempty"`
-	ReqContentType  CurlCfg_ContentType `protobuf:"varint,4,opt,name=reqContentType,proto3,enum=trident.ci.v1.CurlCfg_ContentType" json:"reqContentType,omitempty"`
-	RespContentType CurlCfg_ContentType `protobuf:"varint,5,opt,name=respContentType,proto3,enum=trident.ci.v1.CurlCfg_ContentType" json:"respContentType,omitempty"`
-	ExtraReqHeader  string              `protobuf:"bytes,6,opt,name=extraReqHeader,proto3" json:"extraReqHeader,omitempty"`
-	Timeout         string              `protobuf:"bytes,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// 凭证类型
+	Type CreditType `protobuf:"varint,1,opt,name=type,proto3,enum=trident.ci.v1.CreditType" json:"type,omitempty"`
+	// 用户名
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// 密码,当type=0或type=1（作为私钥的passphrase）时有效
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// ssh私钥,当type=1时有效
+	PrivateKey string `protobuf:"bytes,4,opt,name=privateKey,proto3" json:"privateKey,omitempty"` // 鉴权token,当type=2｜3时有效
 }
 
-func (x *CurlCfg) Reset() {
-	*x = CurlCfg{}
+func (x *Credit) Reset() {
+	*x = Credit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[7]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CurlCfg) String() string {
+func (x *Credit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CurlCfg) ProtoMessage() {}
+func (*Credit) ProtoMessage() {}
 
-func (x *CurlCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[7]
+func (x *Credit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,87 +1207,89 @@ func (x *CurlCfg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CurlCfg.ProtoReflect.Descriptor instead.
-func (*CurlCfg) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Credit.ProtoReflect.Descriptor instead.
+func (*Credit) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CurlCfg) GetUrl() string {
+func (x *Credit) GetType() CreditType {
 	if x != nil {
-		return x.Url
+		return x.Type
 	}
-	return ""
+	return CreditType_NoCredit
 }
 
-func (x *CurlCfg) GetPostData() string {
+func (x *Credit) GetUsername() string {
 	if x != nil {
-		return x.PostData
+		return x.Username
 	}
 	return ""
 }
 
-func (x *CurlCfg) GetReqType() CurlCfg_RequestType {
-	if x != nil {
-		return x.ReqType
-	}
-	return CurlCfg_GET
-}
-
-func (x *CurlCfg) GetReqContentType() CurlCfg_ContentType {
-	if x != nil {
-		return x.ReqContentType
-	}
-	return CurlCfg_JSON
-}
-
-func (x *CurlCfg) GetRespContentType() CurlCfg_ContentType {
-	if x != nil {
-		return x.RespContentType
-	}
-	return CurlCfg_JSON
-}
-
-func (x *CurlCfg) GetExtraReqHeader() string {
+func (x *Credit) GetPassword() string {
 	if x != nil {
-		return x.ExtraReqHeader
+		return x.Password
 	}
 	return ""
 }
 
-func (x *CurlCfg) GetTimeout() string {
+func (x *Credit) GetPrivateKey() string {
 	if x != nil {
-		return x.Timeout
+		return x.PrivateKey
 	}
 	return ""
 }
 
-type Condition struct {
+type ScmCfg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key     string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Target  string            `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Compare Condition_Compare `protobuf:"varint,3,opt,name=compare,proto3,enum=trident.ci.v1.Condition_Compare" json:"compare,omitempty"`
+	VcsType VCSType `protobuf:"varint,1,opt,name=vcsType,proto3,enum=trident.ci.v1.VCSType" json:"vcsType,omitempty"`
+	Address string  `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// svn中为相对仓库地址的路径，如trunk、branches/v1
+	Branch string  `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Credit *Credit `protobuf:"bytes,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	// svn检出的revision，为空时检出HEAD
+	Revision string `protobuf:"bytes,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	// git检出的引用类型
+	RefType RefType `protobuf:"varint,6,opt,name=refType,proto3,enum=trident.ci.v1.RefType" json:"refType,omitempty"`
+	// refType=Tag时为tag名，refType=Commit时为commit sha，
+	// refType=PullRequest时为pr编号（对应refs/pull/<编号>/head）或完整的ref（如refs/merge-requests/1/head）
+	Ref string `protobuf:"bytes,7,opt,name=ref,proto3" json:"ref,omitempty"`
+	// git浅克隆深度，为0时拉取全部历史；refType=Commit时需确保commit在该深度内
+	Depth int32 `protobuf:"varint,8,opt,name=depth,proto3" json:"depth,omitempty"`
+	// git稀疏检出的路径列表（相对仓库根目录），为空时检出全部文件；稀疏检出时不处理子模块，需要构建主机安装git
+	SparsePaths []string `protobuf:"bytes,9,rep,name=sparsePaths,proto3" json:"sparsePaths,omitempty"`
+	// 不拉取git子模块
+	DisableSubmodules bool `protobuf:"varint,10,opt,name=disableSubmodules,proto3" json:"disableSubmodules,omitempty"`
+	// git子模块递归深度，为0时使用默认深度10
+	SubmoduleDepth int32 `protobuf:"varint,11,opt,name=submoduleDepth,proto3" json:"submoduleDepth,omitempty"`
+	// 拉取git lfs文件，需要构建主机安装git及git-lfs
+	FetchLfs bool `protobuf:"varint,12,opt,name=fetchLfs,proto3" json:"fetchLfs,omitempty"`
+	// 检出到工作区下的子路径，为空时检出到工作区根目录；一条流水线检出多个仓库时需各自指定
+	Path string `protobuf:"bytes,13,opt,name=path,proto3" json:"path,omitempty"`
+	// 代码版本信息环境变量的前缀，如APP_对应APP_CI_COMMIT_SHA；为空时若指定了path则由path生成，如shared/config对应SHARED_CONFIG_
+	EnvPrefix string `protobuf:"bytes,14,opt,name=envPrefix,proto3" json:"envPrefix,omitempty"`
 }
 
-func (x *Condition) Reset() {
-	*x = Condition{}
+func (x *ScmCfg) Reset() {
+	*x = ScmCfg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[8]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Condition) String() string {
+func (x *ScmCfg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition) ProtoMessage() {}
+func (*ScmCfg) ProtoMessage() {}
 
-func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[8]
+func (x *ScmCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1146,46 +1300,360 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
-func (*Condition) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ScmCfg.ProtoReflect.Descriptor instead.
+func (*ScmCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Condition) GetKey() string {
+func (x *ScmCfg) GetVcsType() VCSType {
 	if x != nil {
-		return x.Key
+		return x.VcsType
+	}
+	return VCSType_Git
+}
+
+func (x *ScmCfg) GetAddress() string {
+	if x != nil {
+		return x.Address
 	}
 	return ""
 }
 
-func (x *Condition) GetTarget() string {
+func (x *ScmCfg) GetBranch() string {
 	if x != nil {
-		return x.Target
+		return x.Branch
 	}
 	return ""
 }
 
-func (x *Condition) GetCompare() Condition_Compare {
+func (x *ScmCfg) GetCredit() *Credit {
 	if x != nil {
-		return x.Compare
+		return x.Credit
 	}
-	return Condition_Equal
+	return nil
 }
 
-type FlowProgress struct {
+func (x *ScmCfg) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *ScmCfg) GetRefType() RefType {
+	if x != nil {
+		return x.RefType
+	}
+	return RefType_Branch
+}
+
+func (x *ScmCfg) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *ScmCfg) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ScmCfg) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *ScmCfg) GetDisableSubmodules() bool {
+	if x != nil {
+		return x.DisableSubmodules
+	}
+	return false
+}
+
+func (x *ScmCfg) GetSubmoduleDepth() int32 {
+	if x != nil {
+		return x.SubmoduleDepth
+	}
+	return 0
+}
+
+func (x *ScmCfg) GetFetchLfs() bool {
+	if x != nil {
+		return x.FetchLfs
+	}
+	return false
+}
+
+func (x *ScmCfg) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ScmCfg) GetEnvPrefix() string {
+	if x != nil {
+		return x.EnvPrefix
+	}
+	return ""
+}
+
+// 容器挂载
+type VolumeMount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Flow       *Flow  `protobuf:"bytes,1,opt,name=flow,proto3" json:"flow,omitempty"`
-	Status     Status `protobuf:"varint,2,opt,name=status,proto3,enum=trident.ci.v1.Status" json:"status,omitempty"`
-	StartTime  int64  `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	FinishTime int64  `protobuf:"varint,4,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
-	FailReason string `protobuf:"bytes,5,opt,name=failReason,proto3" json:"failReason,omitempty"`
+	// 主机路径（以/开头）或docker volume名
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// 容器内路径
+	Target   string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	ReadOnly bool   `protobuf:"varint,3,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
 }
 
-func (x *FlowProgress) Reset() {
-	*x = FlowProgress{}
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMount) ProtoMessage() {}
+
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VolumeMount) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+type ShellCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cmd string `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	// 是否在docker容器中执行，为false时直接在主机上执行，需服务端开启shell-allow-host
+	WithDocker      bool            `protobuf:"varint,2,opt,name=withDocker,proto3" json:"withDocker,omitempty"`
+	DockerImage     string          `protobuf:"bytes,3,opt,name=dockerImage,proto3" json:"dockerImage,omitempty"`
+	ImagePullPolicy ImagePullPolicy `protobuf:"varint,4,opt,name=imagePullPolicy,proto3,enum=trident.ci.v1.ImagePullPolicy" json:"imagePullPolicy,omitempty"`
+	// 以下为docker模式下的容器配置，需在服务端配置的允许范围内
+	// cpu核数限制，如1.5，为0时使用服务端默认值
+	Cpus float64 `protobuf:"fixed64,5,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// 内存限制(MB)，为0时使用服务端默认值
+	MemoryMb int64 `protobuf:"varint,6,opt,name=memoryMb,proto3" json:"memoryMb,omitempty"`
+	// 运行用户，如1000:1000，为空时使用镜像默认用户
+	User string `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`
+	// 网络模式，如bridge、host或自定义网络名，为空时使用docker默认网络
+	NetworkMode string `protobuf:"bytes,8,opt,name=networkMode,proto3" json:"networkMode,omitempty"`
+	// 额外的挂载
+	Volumes []*VolumeMount `protobuf:"bytes,9,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// tmpfs挂载，key为容器内路径，value为挂载选项，如size=64m
+	Tmpfs map[string]string `protobuf:"bytes,10,rep,name=tmpfs,proto3" json:"tmpfs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 额外的hosts记录，格式为host:ip
+	ExtraHosts []string `protobuf:"bytes,11,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
+	Privileged bool     `protobuf:"varint,12,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	CapAdd     []string `protobuf:"bytes,13,rep,name=capAdd,proto3" json:"capAdd,omitempty"`
+	CapDrop    []string `protobuf:"bytes,14,rep,name=capDrop,proto3" json:"capDrop,omitempty"`
+	// 跨构建的依赖缓存，如~/.m2、node_modules等，仅docker方式运行时生效
+	Caches []*CacheCfg `protobuf:"bytes,15,rep,name=caches,proto3" json:"caches,omitempty"`
+}
+
+func (x *ShellCfg) Reset() {
+	*x = ShellCfg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellCfg) ProtoMessage() {}
+
+func (x *ShellCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellCfg.ProtoReflect.Descriptor instead.
+func (*ShellCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ShellCfg) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *ShellCfg) GetWithDocker() bool {
+	if x != nil {
+		return x.WithDocker
+	}
+	return false
+}
+
+func (x *ShellCfg) GetDockerImage() string {
+	if x != nil {
+		return x.DockerImage
+	}
+	return ""
+}
+
+func (x *ShellCfg) GetImagePullPolicy() ImagePullPolicy {
+	if x != nil {
+		return x.ImagePullPolicy
+	}
+	return ImagePullPolicy_IfNotPresent
+}
+
+func (x *ShellCfg) GetCpus() float64 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *ShellCfg) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *ShellCfg) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ShellCfg) GetNetworkMode() string {
+	if x != nil {
+		return x.NetworkMode
+	}
+	return ""
+}
+
+func (x *ShellCfg) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *ShellCfg) GetTmpfs() map[string]string {
+	if x != nil {
+		return x.Tmpfs
+	}
+	return nil
+}
+
+func (x *ShellCfg) GetExtraHosts() []string {
+	if x != nil {
+		return x.ExtraHosts
+	}
+	return nil
+}
+
+func (x *ShellCfg) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *ShellCfg) GetCapAdd() []string {
+	if x != nil {
+		return x.CapAdd
+	}
+	return nil
+}
+
+func (x *ShellCfg) GetCapDrop() []string {
+	if x != nil {
+		return x.CapDrop
+	}
+	return nil
+}
+
+func (x *ShellCfg) GetCaches() []*CacheCfg {
+	if x != nil {
+		return x.Caches
+	}
+	return nil
+}
+
+// 依赖缓存
+type CacheCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 容器内的缓存路径
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// 缓存key，支持环境变量渲染，key相同的构建共享同一缓存
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// 参与计算key的文件，glob格式，相对于工作区，如go.sum，文件内容的hash会追加到key后
+	KeyFiles []string `protobuf:"bytes,3,rep,name=keyFiles,proto3" json:"keyFiles,omitempty"`
+}
+
+func (x *CacheCfg) Reset() {
+	*x = CacheCfg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_pb_v1_pipeline_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1193,13 +1661,13 @@ func (x *FlowProgress) Reset() {
 	}
 }
 
-func (x *FlowProgress) String() string {
+func (x *CacheCfg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlowProgress) ProtoMessage() {}
+func (*CacheCfg) ProtoMessage() {}
 
-func (x *FlowProgress) ProtoReflect() protoreflect.Message {
+func (x *CacheCfg) ProtoReflect() protoreflect.Message {
 	mi := &file_api_pb_v1_pipeline_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1211,79 +1679,1057 @@ func (x *FlowProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FlowProgress.ProtoReflect.Descriptor instead.
-func (*FlowProgress) Descriptor() ([]byte, []int) {
+// Deprecated: Use CacheCfg.ProtoReflect.Descriptor instead.
+func (*CacheCfg) Descriptor() ([]byte, []int) {
 	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *FlowProgress) GetFlow() *Flow {
+func (x *CacheCfg) GetPath() string {
 	if x != nil {
-		return x.Flow
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-func (x *FlowProgress) GetStatus() Status {
+func (x *CacheCfg) GetKey() string {
 	if x != nil {
-		return x.Status
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CacheCfg) GetKeyFiles() []string {
+	if x != nil {
+		return x.KeyFiles
+	}
+	return nil
+}
+
+type DockerBuildCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 基础镜像，不为空时作为构建参数BASE_IMAGE传入，Dockerfile中可通过ARG BASE_IMAGE引用
+	BaseImage   string `protobuf:"bytes,1,opt,name=baseImage,proto3" json:"baseImage,omitempty"`
+	TargetImage string `protobuf:"bytes,2,opt,name=targetImage,proto3" json:"targetImage,omitempty"`
+	// Dockerfile内容，与dockerfilePath二选一
+	Dockerfile string `protobuf:"bytes,3,opt,name=dockerfile,proto3" json:"dockerfile,omitempty"`
+	// 构建完成后推送targetImage及tags中的全部镜像
+	PushAfterBuild bool `protobuf:"varint,4,opt,name=pushAfterBuild,proto3" json:"pushAfterBuild,omitempty"`
+	// 仓库中Dockerfile的路径，相对于工作区，需位于构建上下文目录内，为空时使用构建上下文目录下的Dockerfile
+	DockerfilePath string `protobuf:"bytes,5,opt,name=dockerfilePath,proto3" json:"dockerfilePath,omitempty"`
+	// 构建上下文目录，相对于工作区，为空时使用工作区根目录
+	ContextDir string `protobuf:"bytes,6,opt,name=contextDir,proto3" json:"contextDir,omitempty"`
+	// 构建参数，值支持环境变量渲染
+	BuildArgs map[string]string `protobuf:"bytes,7,rep,name=buildArgs,proto3" json:"buildArgs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 除targetImage外的其他镜像tag
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 多阶段构建的目标阶段
+	Target  string            `protobuf:"bytes,9,opt,name=target,proto3" json:"target,omitempty"`
+	Labels  map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NoCache bool              `protobuf:"varint,11,opt,name=noCache,proto3" json:"noCache,omitempty"`
+	// 构建时总是拉取基础镜像的最新版本
+	Pull bool `protobuf:"varint,12,opt,name=pull,proto3" json:"pull,omitempty"`
+	// 用作构建缓存的镜像
+	CacheFrom []string `protobuf:"bytes,13,rep,name=cacheFrom,proto3" json:"cacheFrom,omitempty"`
+}
+
+func (x *DockerBuildCfg) Reset() {
+	*x = DockerBuildCfg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DockerBuildCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DockerBuildCfg) ProtoMessage() {}
+
+func (x *DockerBuildCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DockerBuildCfg.ProtoReflect.Descriptor instead.
+func (*DockerBuildCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DockerBuildCfg) GetBaseImage() string {
+	if x != nil {
+		return x.BaseImage
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetTargetImage() string {
+	if x != nil {
+		return x.TargetImage
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetDockerfile() string {
+	if x != nil {
+		return x.Dockerfile
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetPushAfterBuild() bool {
+	if x != nil {
+		return x.PushAfterBuild
+	}
+	return false
+}
+
+func (x *DockerBuildCfg) GetDockerfilePath() string {
+	if x != nil {
+		return x.DockerfilePath
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetContextDir() string {
+	if x != nil {
+		return x.ContextDir
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetBuildArgs() map[string]string {
+	if x != nil {
+		return x.BuildArgs
+	}
+	return nil
+}
+
+func (x *DockerBuildCfg) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DockerBuildCfg) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *DockerBuildCfg) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DockerBuildCfg) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+func (x *DockerBuildCfg) GetPull() bool {
+	if x != nil {
+		return x.Pull
+	}
+	return false
+}
+
+func (x *DockerBuildCfg) GetCacheFrom() []string {
+	if x != nil {
+		return x.CacheFrom
+	}
+	return nil
+}
+
+type LuaCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Script             string `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	ExitWhenErrorOccur bool   `protobuf:"varint,2,opt,name=exitWhenErrorOccur,proto3" json:"exitWhenErrorOccur,omitempty"`
+}
+
+func (x *LuaCfg) Reset() {
+	*x = LuaCfg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LuaCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LuaCfg) ProtoMessage() {}
+
+func (x *LuaCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LuaCfg.ProtoReflect.Descriptor instead.
+func (*LuaCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LuaCfg) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *LuaCfg) GetExitWhenErrorOccur() bool {
+	if x != nil {
+		return x.ExitWhenErrorOccur
+	}
+	return false
+}
+
+type CurlCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url             string              `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	PostData        string              `protobuf:"bytes,2,opt,name=postData,proto3" json:"postData,omitempty"`
+	ReqType         CurlCfg_RequestType `protobuf:"varint,3,opt,name=reqType,proto3,enum=trident.ci.v1.CurlCfg_RequestType" json:"reqType,omitempty"`
+	ReqContentType  CurlCfg_ContentType `protobuf:"varint,4,opt,name=reqContentType,proto3,enum=trident.ci.v1.CurlCfg_ContentType" json:"reqContentType,omitempty"`
+	RespContentType CurlCfg_ContentType `protobuf:"varint,5,opt,name=respContentType,proto3,enum=trident.ci.v1.CurlCfg_ContentType" json:"respContentType,omitempty"`
+	// 额外的请求头，每行一个，格式为Key: Value
+	ExtraReqHeader string `protobuf:"bytes,6,opt,name=extraReqHeader,proto3" json:"extraReqHeader,omitempty"`
+	// 请求超时时间，如10s，为空时不单独限制
+	Timeout string `protobuf:"bytes,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// 视为失败的响应状态码，为空时状态码大于等于400视为失败
+	FailStatusCodes []int32 `protobuf:"varint,8,rep,packed,name=failStatusCodes,proto3" json:"failStatusCodes,omitempty"`
+	// 从响应中提取到环境变量的字段，key为环境变量名，value为JSONPath（如$.data.items[0].id）
+	// 或XPath（如/result/item[1]/@id），按respContentType选择
+	Extract map[string]string `protobuf:"bytes,9,rep,name=extract,proto3" json:"extract,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CurlCfg) Reset() {
+	*x = CurlCfg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CurlCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurlCfg) ProtoMessage() {}
+
+func (x *CurlCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurlCfg.ProtoReflect.Descriptor instead.
+func (*CurlCfg) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CurlCfg) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CurlCfg) GetPostData() string {
+	if x != nil {
+		return x.PostData
+	}
+	return ""
+}
+
+func (x *CurlCfg) GetReqType() CurlCfg_RequestType {
+	if x != nil {
+		return x.ReqType
+	}
+	return CurlCfg_GET
+}
+
+func (x *CurlCfg) GetReqContentType() CurlCfg_ContentType {
+	if x != nil {
+		return x.ReqContentType
+	}
+	return CurlCfg_JSON
+}
+
+func (x *CurlCfg) GetRespContentType() CurlCfg_ContentType {
+	if x != nil {
+		return x.RespContentType
+	}
+	return CurlCfg_JSON
+}
+
+func (x *CurlCfg) GetExtraReqHeader() string {
+	if x != nil {
+		return x.ExtraReqHeader
+	}
+	return ""
+}
+
+func (x *CurlCfg) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *CurlCfg) GetFailStatusCodes() []int32 {
+	if x != nil {
+		return x.FailStatusCodes
+	}
+	return nil
+}
+
+func (x *CurlCfg) GetExtract() map[string]string {
+	if x != nil {
+		return x.Extract
+	}
+	return nil
+}
+
+// 流程执行条件，key为环境变量名，target支持环境变量渲染
+// Less、More在两边均为数字时按数值比较，否则按字符串比较
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Target  string            `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Compare Condition_Compare `protobuf:"varint,3,opt,name=compare,proto3,enum=trident.ci.v1.Condition_Compare" json:"compare,omitempty"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Condition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Condition) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Condition) GetCompare() Condition_Compare {
+	if x != nil {
+		return x.Compare
+	}
+	return Condition_Equal
+}
+
+// 流程的一次执行
+type FlowAttempt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartTime  int64  `protobuf:"varint,1,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	FinishTime int64  `protobuf:"varint,2,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
+	FailReason string `protobuf:"bytes,3,opt,name=failReason,proto3" json:"failReason,omitempty"`
+}
+
+func (x *FlowAttempt) Reset() {
+	*x = FlowAttempt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowAttempt) ProtoMessage() {}
+
+func (x *FlowAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowAttempt.ProtoReflect.Descriptor instead.
+func (*FlowAttempt) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FlowAttempt) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *FlowAttempt) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *FlowAttempt) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+type FlowProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flow       *Flow  `protobuf:"bytes,1,opt,name=flow,proto3" json:"flow,omitempty"`
+	Status     Status `protobuf:"varint,2,opt,name=status,proto3,enum=trident.ci.v1.Status" json:"status,omitempty"`
+	StartTime  int64  `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	FinishTime int64  `protobuf:"varint,4,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
+	FailReason string `protobuf:"bytes,5,opt,name=failReason,proto3" json:"failReason,omitempty"`
+	// 每次执行的记录，配置了重试时可能有多次
+	Attempts []*FlowAttempt `protobuf:"bytes,6,rep,name=attempts,proto3" json:"attempts,omitempty"`
+}
+
+func (x *FlowProgress) Reset() {
+	*x = FlowProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowProgress) ProtoMessage() {}
+
+func (x *FlowProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowProgress.ProtoReflect.Descriptor instead.
+func (*FlowProgress) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FlowProgress) GetFlow() *Flow {
+	if x != nil {
+		return x.Flow
+	}
+	return nil
+}
+
+func (x *FlowProgress) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_Created
+}
+
+func (x *FlowProgress) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *FlowProgress) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *FlowProgress) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *FlowProgress) GetAttempts() []*FlowAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type StageProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status     Status `protobuf:"varint,2,opt,name=status,proto3,enum=trident.ci.v1.Status" json:"status,omitempty"`
+	StartTime  int64  `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	FinishTime int64  `protobuf:"varint,4,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
+	FailReason string `protobuf:"bytes,5,opt,name=failReason,proto3" json:"failReason,omitempty"`
+	// 阶段内流程在PipelineProgress.flowProgresses中的索引
+	FlowIndexes []int32 `protobuf:"varint,6,rep,packed,name=flowIndexes,proto3" json:"flowIndexes,omitempty"`
+}
+
+func (x *StageProgress) Reset() {
+	*x = StageProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StageProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageProgress) ProtoMessage() {}
+
+func (x *StageProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageProgress.ProtoReflect.Descriptor instead.
+func (*StageProgress) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StageProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StageProgress) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_Created
+}
+
+func (x *StageProgress) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *StageProgress) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *StageProgress) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *StageProgress) GetFlowIndexes() []int32 {
+	if x != nil {
+		return x.FlowIndexes
+	}
+	return nil
+}
+
+type PipelineProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pipeline         *Pipeline         `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	Status           Status            `protobuf:"varint,2,opt,name=status,proto3,enum=trident.ci.v1.Status" json:"status,omitempty"`
+	StartTime        int64             `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	CreateTime       int64             `protobuf:"varint,4,opt,name=createTime,proto3" json:"createTime,omitempty"`
+	FinishTime       int64             `protobuf:"varint,5,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
+	FailReason       string            `protobuf:"bytes,6,opt,name=failReason,proto3" json:"failReason,omitempty"`
+	FlowProgresses   []*FlowProgress   `protobuf:"bytes,7,rep,name=flowProgresses,proto3" json:"flowProgresses,omitempty"`
+	CurRunningFlowId string            `protobuf:"bytes,8,opt,name=curRunningFlowId,proto3" json:"curRunningFlowId,omitempty"`
+	Env              map[string]string `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 使用stages时各阶段的进度
+	StageProgresses     []*StageProgress `protobuf:"bytes,10,rep,name=stageProgresses,proto3" json:"stageProgresses,omitempty"`
+	OnSuccessProgresses []*FlowProgress  `protobuf:"bytes,11,rep,name=onSuccessProgresses,proto3" json:"onSuccessProgresses,omitempty"`
+	OnFailureProgresses []*FlowProgress  `protobuf:"bytes,12,rep,name=onFailureProgresses,proto3" json:"onFailureProgresses,omitempty"`
+	FinallyProgresses   []*FlowProgress  `protobuf:"bytes,13,rep,name=finallyProgresses,proto3" json:"finallyProgresses,omitempty"`
+}
+
+func (x *PipelineProgress) Reset() {
+	*x = PipelineProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PipelineProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineProgress) ProtoMessage() {}
+
+func (x *PipelineProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineProgress.ProtoReflect.Descriptor instead.
+func (*PipelineProgress) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PipelineProgress) GetPipeline() *Pipeline {
+	if x != nil {
+		return x.Pipeline
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_Created
+}
+
+func (x *PipelineProgress) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *PipelineProgress) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *PipelineProgress) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *PipelineProgress) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *PipelineProgress) GetFlowProgresses() []*FlowProgress {
+	if x != nil {
+		return x.FlowProgresses
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetCurRunningFlowId() string {
+	if x != nil {
+		return x.CurRunningFlowId
+	}
+	return ""
+}
+
+func (x *PipelineProgress) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetStageProgresses() []*StageProgress {
+	if x != nil {
+		return x.StageProgresses
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetOnSuccessProgresses() []*FlowProgress {
+	if x != nil {
+		return x.OnSuccessProgresses
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetOnFailureProgresses() []*FlowProgress {
+	if x != nil {
+		return x.OnFailureProgresses
+	}
+	return nil
+}
+
+func (x *PipelineProgress) GetFinallyProgresses() []*FlowProgress {
+	if x != nil {
+		return x.FinallyProgresses
+	}
+	return nil
+}
+
+type BuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pipeline *Pipeline `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+}
+
+func (x *BuildRequest) Reset() {
+	*x = BuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildRequest) ProtoMessage() {}
+
+func (x *BuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildRequest.ProtoReflect.Descriptor instead.
+func (*BuildRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BuildRequest) GetPipeline() *Pipeline {
+	if x != nil {
+		return x.Pipeline
+	}
+	return nil
+}
+
+type BuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
+}
+
+func (x *BuildResponse) Reset() {
+	*x = BuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildResponse) ProtoMessage() {}
+
+func (x *BuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildResponse.ProtoReflect.Descriptor instead.
+func (*BuildResponse) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BuildResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+type GetBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
+}
+
+func (x *GetBuildRequest) Reset() {
+	*x = GetBuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildRequest) ProtoMessage() {}
+
+func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBuildRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+type BuildDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Progress *PipelineProgress `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (x *BuildDetail) Reset() {
+	*x = BuildDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildDetail) ProtoMessage() {}
+
+func (x *BuildDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildDetail.ProtoReflect.Descriptor instead.
+func (*BuildDetail) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BuildDetail) GetProgress() *PipelineProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+type ListBuildsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListBuildsRequest) Reset() {
+	*x = ListBuildsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return Status_Created
 }
 
-func (x *FlowProgress) GetStartTime() int64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
+func (x *ListBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *FlowProgress) GetFinishTime() int64 {
-	if x != nil {
-		return x.FinishTime
+func (*ListBuildsRequest) ProtoMessage() {}
+
+func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *FlowProgress) GetFailReason() string {
-	if x != nil {
-		return x.FailReason
-	}
-	return ""
+// Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{22}
 }
 
-type PipelineProgress struct {
+type ListBuildsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pipeline         *Pipeline         `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
-	Status           Status            `protobuf:"varint,2,opt,name=status,proto3,enum=trident.ci.v1.Status" json:"status,omitempty"`
-	StartTime        int64             `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	CreateTime       int64             `protobuf:"varint,4,opt,name=createTime,proto3" json:"createTime,omitempty"`
-	FinishTime       int64             `protobuf:"varint,5,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
-	FailReason       string            `protobuf:"bytes,6,opt,name=failReason,proto3" json:"failReason,omitempty"`
-	FlowProgresses   []*FlowProgress   `protobuf:"bytes,7,rep,name=flowProgresses,proto3" json:"flowProgresses,omitempty"`
-	CurRunningFlowId string            `protobuf:"bytes,8,opt,name=curRunningFlowId,proto3" json:"curRunningFlowId,omitempty"`
-	Env              map[string]string `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 按创建时间倒序
+	Progresses []*PipelineProgress `protobuf:"bytes,1,rep,name=progresses,proto3" json:"progresses,omitempty"`
 }
 
-func (x *PipelineProgress) Reset() {
-	*x = PipelineProgress{}
+func (x *ListBuildsResponse) Reset() {
+	*x = ListBuildsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[10]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PipelineProgress) String() string {
+func (x *ListBuildsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PipelineProgress) ProtoMessage() {}
+func (*ListBuildsResponse) ProtoMessage() {}
 
-func (x *PipelineProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[10]
+func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,99 +2740,90 @@ func (x *PipelineProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PipelineProgress.ProtoReflect.Descriptor instead.
-func (*PipelineProgress) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *PipelineProgress) GetPipeline() *Pipeline {
+func (x *ListBuildsResponse) GetProgresses() []*PipelineProgress {
 	if x != nil {
-		return x.Pipeline
+		return x.Progresses
 	}
 	return nil
 }
 
-func (x *PipelineProgress) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_Created
-}
+type DeleteBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *PipelineProgress) GetStartTime() int64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
+	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
 }
 
-func (x *PipelineProgress) GetCreateTime() int64 {
-	if x != nil {
-		return x.CreateTime
+func (x *DeleteBuildRequest) Reset() {
+	*x = DeleteBuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *PipelineProgress) GetFinishTime() int64 {
-	if x != nil {
-		return x.FinishTime
-	}
-	return 0
+func (x *DeleteBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PipelineProgress) GetFailReason() string {
-	if x != nil {
-		return x.FailReason
-	}
-	return ""
-}
+func (*DeleteBuildRequest) ProtoMessage() {}
 
-func (x *PipelineProgress) GetFlowProgresses() []*FlowProgress {
-	if x != nil {
-		return x.FlowProgresses
+func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *PipelineProgress) GetCurRunningFlowId() string {
-	if x != nil {
-		return x.CurRunningFlowId
-	}
-	return ""
+// Deprecated: Use DeleteBuildRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBuildRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *PipelineProgress) GetEnv() map[string]string {
+func (x *DeleteBuildRequest) GetBuildId() string {
 	if x != nil {
-		return x.Env
+		return x.BuildId
 	}
-	return nil
+	return ""
 }
 
-type BuildRequest struct {
+type StopBuildRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pipeline *Pipeline `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
 }
 
-func (x *BuildRequest) Reset() {
-	*x = BuildRequest{}
+func (x *StopBuildRequest) Reset() {
+	*x = StopBuildRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[11]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BuildRequest) String() string {
+func (x *StopBuildRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuildRequest) ProtoMessage() {}
+func (*StopBuildRequest) ProtoMessage() {}
 
-func (x *BuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[11]
+func (x *StopBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1397,43 +2834,41 @@ func (x *BuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuildRequest.ProtoReflect.Descriptor instead.
-func (*BuildRequest) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use StopBuildRequest.ProtoReflect.Descriptor instead.
+func (*StopBuildRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *BuildRequest) GetPipeline() *Pipeline {
+func (x *StopBuildRequest) GetBuildId() string {
 	if x != nil {
-		return x.Pipeline
+		return x.BuildId
 	}
-	return nil
+	return ""
 }
 
-type BuildResponse struct {
+type EmptyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
 }
 
-func (x *BuildResponse) Reset() {
-	*x = BuildResponse{}
+func (x *EmptyResponse) Reset() {
+	*x = EmptyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[12]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BuildResponse) String() string {
+func (x *EmptyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuildResponse) ProtoMessage() {}
+func (*EmptyResponse) ProtoMessage() {}
 
-func (x *BuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[12]
+func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1444,43 +2879,40 @@ func (x *BuildResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuildResponse.ProtoReflect.Descriptor instead.
-func (*BuildResponse) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *BuildResponse) GetBuildId() string {
-	if x != nil {
-		return x.BuildId
-	}
-	return ""
+// Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
+func (*EmptyResponse) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{26}
 }
 
-type GetBuildRequest struct {
+// 构建产物
+type Artifact struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
+	// 相对于工作区的路径
+	Path    string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size    int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModTime int64  `protobuf:"varint,3,opt,name=modTime,proto3" json:"modTime,omitempty"`
 }
 
-func (x *GetBuildRequest) Reset() {
-	*x = GetBuildRequest{}
+func (x *Artifact) Reset() {
+	*x = Artifact{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[13]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetBuildRequest) String() string {
+func (x *Artifact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBuildRequest) ProtoMessage() {}
+func (*Artifact) ProtoMessage() {}
 
-func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[13]
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1491,43 +2923,57 @@ func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
-func (*GetBuildRequest) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GetBuildRequest) GetBuildId() string {
+func (x *Artifact) GetPath() string {
 	if x != nil {
-		return x.BuildId
+		return x.Path
 	}
 	return ""
 }
 
-type BuildDetail struct {
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+type ListArtifactsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Progress *PipelineProgress `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
 }
 
-func (x *BuildDetail) Reset() {
-	*x = BuildDetail{}
+func (x *ListArtifactsRequest) Reset() {
+	*x = ListArtifactsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[14]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BuildDetail) String() string {
+func (x *ListArtifactsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuildDetail) ProtoMessage() {}
+func (*ListArtifactsRequest) ProtoMessage() {}
 
-func (x *BuildDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[14]
+func (x *ListArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1538,43 +2984,43 @@ func (x *BuildDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuildDetail.ProtoReflect.Descriptor instead.
-func (*BuildDetail) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *BuildDetail) GetProgress() *PipelineProgress {
+func (x *ListArtifactsRequest) GetBuildId() string {
 	if x != nil {
-		return x.Progress
+		return x.BuildId
 	}
-	return nil
+	return ""
 }
 
-type DeleteBuildRequest struct {
+type ListArtifactsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
+	Artifacts []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
 }
 
-func (x *DeleteBuildRequest) Reset() {
-	*x = DeleteBuildRequest{}
+func (x *ListArtifactsResponse) Reset() {
+	*x = ListArtifactsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[15]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteBuildRequest) String() string {
+func (x *ListArtifactsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBuildRequest) ProtoMessage() {}
+func (*ListArtifactsResponse) ProtoMessage() {}
 
-func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[15]
+func (x *ListArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1585,43 +3031,44 @@ func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBuildRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBuildRequest) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *DeleteBuildRequest) GetBuildId() string {
+func (x *ListArtifactsResponse) GetArtifacts() []*Artifact {
 	if x != nil {
-		return x.BuildId
+		return x.Artifacts
 	}
-	return ""
+	return nil
 }
 
-type StopBuildRequest struct {
+type DownloadArtifactRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	BuildId string `protobuf:"bytes,1,opt,name=buildId,proto3" json:"buildId,omitempty"`
+	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *StopBuildRequest) Reset() {
-	*x = StopBuildRequest{}
+func (x *DownloadArtifactRequest) Reset() {
+	*x = DownloadArtifactRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[16]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StopBuildRequest) String() string {
+func (x *DownloadArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopBuildRequest) ProtoMessage() {}
+func (*DownloadArtifactRequest) ProtoMessage() {}
 
-func (x *StopBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[16]
+func (x *DownloadArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1632,41 +3079,50 @@ func (x *StopBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopBuildRequest.ProtoReflect.Descriptor instead.
-func (*StopBuildRequest) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use DownloadArtifactRequest.ProtoReflect.Descriptor instead.
+func (*DownloadArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *StopBuildRequest) GetBuildId() string {
+func (x *DownloadArtifactRequest) GetBuildId() string {
 	if x != nil {
 		return x.BuildId
 	}
 	return ""
 }
 
-type EmptyResponse struct {
+func (x *DownloadArtifactRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ArtifactChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *EmptyResponse) Reset() {
-	*x = EmptyResponse{}
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_pb_v1_pipeline_proto_msgTypes[17]
+		mi := &file_api_pb_v1_pipeline_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EmptyResponse) String() string {
+func (x *ArtifactChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyResponse) ProtoMessage() {}
+func (*ArtifactChunk) ProtoMessage() {}
 
-func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_pb_v1_pipeline_proto_msgTypes[17]
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pb_v1_pipeline_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1677,9 +3133,16 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
-func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_api_pb_v1_pipeline_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
 }
 
 var File_api_pb_v1_pipeline_proto protoreflect.FileDescriptor
@@ -1687,7 +3150,7 @@ var File_api_pb_v1_pipeline_proto protoreflect.FileDescriptor
 var file_api_pb_v1_pipeline_proto_rawDesc = []byte{
 	0x0a, 0x18, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x69, 0x70, 0x65,
 	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x72, 0x69, 0x64,
-	0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x22, 0xeb, 0x01, 0x0a, 0x08, 0x50, 0x69,
+	0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x22, 0xe3, 0x04, 0x0a, 0x08, 0x50, 0x69,
 	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61,
 	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x14,
@@ -1698,115 +3161,290 @@ var file_api_pb_v1_pipeline_proto_rawDesc = []byte{
 	0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x23, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
 	0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc1, 0x02, 0x0a, 0x04, 0x46, 0x6c, 0x6f, 0x77,
-	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
-	0x69, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x2d, 0x0a, 0x06, 0x73, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x52, 0x06, 0x73, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x12, 0x33,
-	0x0a, 0x08, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x52, 0x08, 0x73, 0x68, 0x65, 0x6c, 0x6c,
-	0x43, 0x66, 0x67, 0x12, 0x45, 0x0a, 0x0e, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x43, 0x66, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b,
-	0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x52, 0x0e, 0x64, 0x6f, 0x63, 0x6b,
-	0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x75,
-	0x61, 0x43, 0x66, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x69,
-	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x75, 0x61, 0x43, 0x66,
-	0x67, 0x52, 0x06, 0x6c, 0x75, 0x61, 0x43, 0x66, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x6f, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
-	0x6e, 0x6f, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x8f, 0x01, 0x0a, 0x06,
-	0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a,
-	0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x9b, 0x01,
-	0x0a, 0x06, 0x53, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x12, 0x30, 0x0a, 0x07, 0x76, 0x63, 0x73, 0x54,
-	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x69, 0x64,
-	0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x43, 0x53, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x07, 0x76, 0x63, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2d, 0x0a, 0x06,
-	0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74,
-	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
-	0x64, 0x69, 0x74, 0x52, 0x06, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x08,
-	0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x69,
-	0x74, 0x68, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
-	0x77, 0x69, 0x74, 0x68, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x6f,
-	0x63, 0x6b, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x0f,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
-	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50,
-	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c,
-	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x98, 0x01, 0x0a, 0x0e, 0x44, 0x6f, 0x63, 0x6b, 0x65,
-	0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x73,
-	0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61,
-	0x73, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x6f, 0x63,
-	0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
-	0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x75, 0x73,
-	0x68, 0x41, 0x66, 0x74, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0e, 0x70, 0x75, 0x73, 0x68, 0x41, 0x66, 0x74, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x22, 0x50, 0x0a, 0x06, 0x4c, 0x75, 0x61, 0x43, 0x66, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x78, 0x69, 0x74, 0x57, 0x68, 0x65, 0x6e, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x12, 0x65, 0x78, 0x69, 0x74, 0x57, 0x68, 0x65, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4f, 0x63,
-	0x63, 0x75, 0x72, 0x22, 0xbf, 0x03, 0x0a, 0x07, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x12,
-	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
-	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a,
-	0x07, 0x72, 0x65, 0x71, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22,
-	0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
-	0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x07, 0x72, 0x65, 0x71, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4a, 0x0a, 0x0e, 0x72,
-	0x65, 0x71, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x22, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x65,
-	0x71, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65,
-	0x78, 0x74, 0x72, 0x61, 0x52, 0x65, 0x71, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x35, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12,
-	0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54,
-	0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x22, 0x35,
-	0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a,
-	0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6d, 0x10,
-	0x01, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x69, 0x6e, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03,
-	0x58, 0x6d, 0x6c, 0x10, 0x03, 0x22, 0xa9, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x3a, 0x0a,
-	0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x47, 0x0a, 0x0f,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
+	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x43, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x43, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x6b, 0x65, 0x65, 0x70, 0x52, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x14, 0x6b, 0x65, 0x65, 0x70, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x6e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x52, 0x09, 0x6f,
+	0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x6f, 0x6e, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77,
+	0x52, 0x09, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x66,
+	0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74,
+	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f,
+	0x77, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x62, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x05,
+	0x66, 0x6c, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77,
+	0x52, 0x05, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c,
+	0x6c, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x61, 0x72, 0x61, 0x6c,
+	0x6c, 0x65, 0x6c, 0x22, 0x74, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x43,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xaf, 0x05, 0x0a, 0x04, 0x46, 0x6c,
+	0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x52, 0x06, 0x73, 0x63, 0x6d, 0x43, 0x66, 0x67,
+	0x12, 0x33, 0x0a, 0x08, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x52, 0x08, 0x73, 0x68, 0x65,
+	0x6c, 0x6c, 0x43, 0x66, 0x67, 0x12, 0x45, 0x0a, 0x0e, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f,
+	0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x52, 0x0e, 0x64, 0x6f,
+	0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x12, 0x2d, 0x0a, 0x06,
+	0x6c, 0x75, 0x61, 0x43, 0x66, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74,
+	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x75, 0x61,
+	0x43, 0x66, 0x67, 0x52, 0x06, 0x6c, 0x75, 0x61, 0x43, 0x66, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x6e,
+	0x6f, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x6e, 0x6f, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x51, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x21, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x6c, 0x43, 0x66,
+	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x52,
+	0x07, 0x63, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x43, 0x66, 0x67,
+	0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x75, 0x65, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xbb, 0x01, 0x0a, 0x08,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x43, 0x66, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3d, 0x0a, 0x0c,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09,
+	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x06, 0x43, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x22, 0xd7, 0x03, 0x0a, 0x06,
+	0x53, 0x63, 0x6d, 0x43, 0x66, 0x67, 0x12, 0x30, 0x0a, 0x07, 0x76, 0x63, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x43, 0x53, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x07, 0x76, 0x63, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x52, 0x06, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
+	0x72, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x73, 0x12, 0x2c, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x75, 0x62, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x44, 0x65, 0x70, 0x74,
+	0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x4c, 0x66, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x4c, 0x66, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x76, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x76, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x59, 0x0a, 0x0b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79,
+	0x22, 0xdb, 0x04, 0x0a, 0x08, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x77, 0x69, 0x74, 0x68, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x74, 0x68, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x12, 0x48, 0x0a, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x70, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x70, 0x75, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x34, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x05, 0x74, 0x6d, 0x70, 0x66, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x66, 0x67, 0x2e,
+	0x54, 0x6d, 0x70, 0x66, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x74, 0x6d, 0x70, 0x66,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x48, 0x6f, 0x73, 0x74,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x70, 0x41, 0x64, 0x64, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x61, 0x70, 0x41, 0x64, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x70,
+	0x44, 0x72, 0x6f, 0x70, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x61, 0x70, 0x44,
+	0x72, 0x6f, 0x70, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x43, 0x66, 0x67, 0x52, 0x06, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x54, 0x6d, 0x70, 0x66, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c,
+	0x0a, 0x08, 0x43, 0x61, 0x63, 0x68, 0x65, 0x43, 0x66, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0xe0, 0x04, 0x0a,
+	0x0e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x12,
+	0x1c, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x26, 0x0a, 0x0e, 0x70, 0x75, 0x73, 0x68, 0x41, 0x66, 0x74, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x75, 0x73, 0x68, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x6f, 0x63, 0x6b, 0x65,
+	0x72, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x44, 0x69, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x44, 0x69, 0x72, 0x12,
+	0x4a, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x66,
+	0x67, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x43, 0x66, 0x67, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x6c, 0x6c, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x70, 0x75, 0x6c, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x1a, 0x3c, 0x0a, 0x0e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41,
+	0x72, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x50, 0x0a, 0x06, 0x4c, 0x75, 0x61, 0x43, 0x66, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x78, 0x69, 0x74, 0x57, 0x68, 0x65, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x65,
+	0x78, 0x69, 0x74, 0x57, 0x68, 0x65, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4f, 0x63, 0x63, 0x75,
+	0x72, 0x22, 0xe4, 0x04, 0x0a, 0x07, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x74,
+	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72,
+	0x6c, 0x43, 0x66, 0x67, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x07, 0x72, 0x65, 0x71, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4a, 0x0a, 0x0e, 0x72, 0x65, 0x71,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x22, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22,
 	0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
-	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x22, 0x36, 0x0a, 0x07, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x10, 0x00, 0x12,
-	0x08, 0x0a, 0x04, 0x4c, 0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x6f, 0x72,
-	0x65, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x10,
-	0x03, 0x22, 0xc4, 0x01, 0x0a, 0x0c, 0x46, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x65, 0x71, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x74,
+	0x72, 0x61, 0x52, 0x65, 0x71, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f,
+	0x66, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x3d, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x75, 0x72, 0x6c, 0x43, 0x66, 0x67, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x1a, 0x3a,
+	0x0a, 0x0c, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x0b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
+	0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10,
+	0x03, 0x22, 0x35, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x6f,
+	0x72, 0x6d, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x69, 0x6e, 0x10, 0x02, 0x12,
+	0x07, 0x0a, 0x03, 0x58, 0x6d, 0x6c, 0x10, 0x03, 0x22, 0xa9, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x3a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x20, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x22, 0x36, 0x0a, 0x07,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x71, 0x75, 0x61, 0x6c,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
+	0x4d, 0x6f, 0x72, 0x65, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x73, 0x10, 0x03, 0x22, 0x6b, 0x0a, 0x0b, 0x46, 0x6c, 0x6f, 0x77, 0x41, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0xfc, 0x01, 0x0a, 0x0c, 0x46, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
 	0x73, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x13, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
 	0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x52, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x2d, 0x0a, 0x06, 0x73,
@@ -1818,103 +3456,194 @@ var file_api_pb_v1_pipeline_proto_rawDesc = []byte{
 	0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69,
 	0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c,
 	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61,
-	0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xd9, 0x03, 0x0a, 0x10, 0x50, 0x69, 0x70,
-	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x33, 0x0a,
-	0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
-	0x6e, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
-	0x43, 0x0a, 0x0e, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
-	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67,
-	0x72, 0x65, 0x73, 0x73, 0x52, 0x0e, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x75, 0x72, 0x52, 0x75, 0x6e, 0x6e, 0x69,
-	0x6e, 0x67, 0x46, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x63, 0x75, 0x72, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x6f, 0x77, 0x49, 0x64,
-	0x12, 0x3a, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
-	0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69,
-	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x45,
-	0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x1a, 0x36, 0x0a, 0x08,
-	0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
-	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
-	0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x29, 0x0a, 0x0d, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69,
-	0x6c, 0x64, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
-	0x64, 0x22, 0x4a, 0x0a, 0x0b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x12, 0x3b, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72,
-	0x65, 0x73, 0x73, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x2e, 0x0a,
-	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x2c, 0x0a,
-	0x10, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x22, 0xd2, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0x8a, 0x06, 0x0a, 0x10, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74,
+	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x0e,
+	0x66, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x0e, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x75, 0x72, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x46,
+	0x6c, 0x6f, 0x77, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x75, 0x72,
+	0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x3a, 0x0a,
+	0x03, 0x65, 0x6e, 0x76, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x45, 0x6e, 0x76, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x46, 0x0a, 0x0f, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x0f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x4d, 0x0a, 0x13, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x13, 0x6f, 0x6e, 0x53,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x12, 0x4d, 0x0a, 0x13, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c,
+	0x6f, 0x77, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x13, 0x6f, 0x6e, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12,
+	0x49, 0x0a, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x43, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x29, 0x0a, 0x0d, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x49, 0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22,
+	0x4a, 0x0a, 0x0b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x3b,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x55, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0a, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x2e, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x70, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4c, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x22, 0x47, 0x0a, 0x17, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x38, 0x0a, 0x08,
-	0x46, 0x6c, 0x6f, 0x77, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x43, 0x4d, 0x10,
-	0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b,
-	0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x10, 0x02, 0x12, 0x07, 0x0a,
-	0x03, 0x4c, 0x75, 0x61, 0x10, 0x03, 0x2a, 0x1b, 0x0a, 0x07, 0x56, 0x43, 0x53, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x69, 0x74, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x56,
-	0x4e, 0x10, 0x01, 0x2a, 0x6c, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x10, 0x00, 0x12,
-	0x0f, 0x0a, 0x0b, 0x54, 0x79, 0x70, 0x65, 0x55, 0x73, 0x65, 0x72, 0x50, 0x77, 0x64, 0x10, 0x01,
-	0x12, 0x15, 0x0a, 0x11, 0x54, 0x79, 0x70, 0x65, 0x53, 0x53, 0x48, 0x50, 0x72, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x4b, 0x65, 0x79, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x79, 0x70, 0x65, 0x47,
-	0x69, 0x74, 0x6c, 0x61, 0x62, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f,
-	0x54, 0x79, 0x70, 0x65, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10,
-	0x04, 0x2a, 0x3a, 0x0a, 0x0f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f,
-	0x6c, 0x69, 0x63, 0x79, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x66, 0x4e, 0x6f, 0x74, 0x50, 0x72, 0x65,
-	0x73, 0x65, 0x6e, 0x74, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x6c, 0x77, 0x61, 0x79, 0x73,
-	0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x65, 0x76, 0x65, 0x72, 0x10, 0x02, 0x2a, 0x25, 0x0a,
-	0x12, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6e, 0x64, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02,
-	0x4f, 0x72, 0x10, 0x01, 0x2a, 0x56, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b,
-	0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e,
-	0x69, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64,
-	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x04, 0x12, 0x0c,
-	0x0a, 0x08, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x32, 0xb5, 0x02, 0x0a,
-	0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x42, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12,
-	0x1b, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74,
-	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x2e, 0x74,
-	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74,
-	0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x4e, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x21, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e,
-	0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x72, 0x69,
-	0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1f, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
-	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22,
+	0x23, 0x0a, 0x0d, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x2a, 0x42, 0x0a, 0x08, 0x46, 0x6c, 0x6f, 0x77, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x07, 0x0a, 0x03, 0x53, 0x43, 0x4d, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x68, 0x65,
+	0x6c, 0x6c, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x75, 0x61, 0x10, 0x03, 0x12, 0x08,
+	0x0a, 0x04, 0x43, 0x75, 0x72, 0x6c, 0x10, 0x04, 0x2a, 0x30, 0x0a, 0x0a, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x01, 0x2a, 0x1b, 0x0a, 0x07, 0x56, 0x43,
+	0x53, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x69, 0x74, 0x10, 0x00, 0x12, 0x07,
+	0x0a, 0x03, 0x53, 0x56, 0x4e, 0x10, 0x01, 0x2a, 0x6c, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x43, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x79, 0x70, 0x65, 0x55, 0x73, 0x65, 0x72, 0x50,
+	0x77, 0x64, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x79, 0x70, 0x65, 0x53, 0x53, 0x48, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x54,
+	0x79, 0x70, 0x65, 0x47, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0x03,
+	0x12, 0x13, 0x0a, 0x0f, 0x54, 0x79, 0x70, 0x65, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x10, 0x04, 0x2a, 0x3b, 0x0a, 0x07, 0x52, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0a, 0x0a, 0x06, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
+	0x54, 0x61, 0x67, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x10, 0x03, 0x2a, 0x3a, 0x0a, 0x0f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x66, 0x4e, 0x6f, 0x74, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x74, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x6c, 0x77, 0x61, 0x79,
+	0x73, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x65, 0x76, 0x65, 0x72, 0x10, 0x02, 0x2a, 0x25,
+	0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6e, 0x64, 0x10, 0x00, 0x12, 0x06, 0x0a,
+	0x02, 0x4f, 0x72, 0x10, 0x01, 0x2a, 0x9d, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x0b, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x65, 0x64, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x04,
+	0x12, 0x0c, 0x0a, 0x08, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x10, 0x07, 0x12, 0x17,
+	0x0a, 0x13, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x57, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x64,
+	0x4f, 0x75, 0x74, 0x10, 0x09, 0x32, 0xc0, 0x04, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12,
+	0x42, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1b, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
+	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
+	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e,
+	0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x12, 0x51, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12,
+	0x20, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x12, 0x21, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
 	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x04, 0x5a, 0x02, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x12, 0x1f, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5a, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x73, 0x12, 0x23, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x10,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x12, 0x26, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x72, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x2e, 0x63, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x42, 0x04, 0x5a, 0x02, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1929,76 +3658,128 @@ func file_api_pb_v1_pipeline_proto_rawDescGZIP() []byte {
 	return file_api_pb_v1_pipeline_proto_rawDescData
 }
 
-var file_api_pb_v1_pipeline_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
-var file_api_pb_v1_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_pb_v1_pipeline_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_api_pb_v1_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_api_pb_v1_pipeline_proto_goTypes = []interface{}{
-	(FlowType)(0),              // 0: trident.ci.v1.FlowType
-	(VCSType)(0),               // 1: trident.ci.v1.VCSType
-	(CreditType)(0),            // 2: trident.ci.v1.CreditType
-	(ImagePullPolicy)(0),       // 3: trident.ci.v1.ImagePullPolicy
-	(ConditionConnector)(0),    // 4: trident.ci.v1.ConditionConnector
-	(Status)(0),                // 5: trident.ci.v1.Status
-	(CurlCfg_RequestType)(0),   // 6: trident.ci.v1.CurlCfg.RequestType
-	(CurlCfg_ContentType)(0),   // 7: trident.ci.v1.CurlCfg.ContentType
-	(Condition_Compare)(0),     // 8: trident.ci.v1.Condition.Compare
-	(*Pipeline)(nil),           // 9: trident.ci.v1.Pipeline
-	(*Flow)(nil),               // 10: trident.ci.v1.Flow
-	(*Credit)(nil),             // 11: trident.ci.v1.Credit
-	(*ScmCfg)(nil),             // 12: trident.ci.v1.ScmCfg
-	(*ShellCfg)(nil),           // 13: trident.ci.v1.ShellCfg
-	(*DockerBuildCfg)(nil),     // 14: trident.ci.v1.DockerBuildCfg
-	(*LuaCfg)(nil),             // 15: trident.ci.v1.LuaCfg
-	(*CurlCfg)(nil),            // 16: trident.ci.v1.CurlCfg
-	(*Condition)(nil),          // 17: trident.ci.v1.Condition
-	(*FlowProgress)(nil),       // 18: trident.ci.v1.FlowProgress
-	(*PipelineProgress)(nil),   // 19: trident.ci.v1.PipelineProgress
-	(*BuildRequest)(nil),       // 20: trident.ci.v1.BuildRequest
-	(*BuildResponse)(nil),      // 21: trident.ci.v1.BuildResponse
-	(*GetBuildRequest)(nil),    // 22: trident.ci.v1.GetBuildRequest
-	(*BuildDetail)(nil),        // 23: trident.ci.v1.BuildDetail
-	(*DeleteBuildRequest)(nil), // 24: trident.ci.v1.DeleteBuildRequest
-	(*StopBuildRequest)(nil),   // 25: trident.ci.v1.StopBuildRequest
-	(*EmptyResponse)(nil),      // 26: trident.ci.v1.EmptyResponse
-	nil,                        // 27: trident.ci.v1.Pipeline.ParamsEntry
-	nil,                        // 28: trident.ci.v1.PipelineProgress.EnvEntry
+	(FlowType)(0),                   // 0: trident.ci.v1.FlowType
+	(ErrorClass)(0),                 // 1: trident.ci.v1.ErrorClass
+	(VCSType)(0),                    // 2: trident.ci.v1.VCSType
+	(CreditType)(0),                 // 3: trident.ci.v1.CreditType
+	(RefType)(0),                    // 4: trident.ci.v1.RefType
+	(ImagePullPolicy)(0),            // 5: trident.ci.v1.ImagePullPolicy
+	(ConditionConnector)(0),         // 6: trident.ci.v1.ConditionConnector
+	(Status)(0),                     // 7: trident.ci.v1.Status
+	(CurlCfg_RequestType)(0),        // 8: trident.ci.v1.CurlCfg.RequestType
+	(CurlCfg_ContentType)(0),        // 9: trident.ci.v1.CurlCfg.ContentType
+	(Condition_Compare)(0),          // 10: trident.ci.v1.Condition.Compare
+	(*Pipeline)(nil),                // 11: trident.ci.v1.Pipeline
+	(*Stage)(nil),                   // 12: trident.ci.v1.Stage
+	(*RegistryCredit)(nil),          // 13: trident.ci.v1.RegistryCredit
+	(*Flow)(nil),                    // 14: trident.ci.v1.Flow
+	(*RetryCfg)(nil),                // 15: trident.ci.v1.RetryCfg
+	(*Credit)(nil),                  // 16: trident.ci.v1.Credit
+	(*ScmCfg)(nil),                  // 17: trident.ci.v1.ScmCfg
+	(*VolumeMount)(nil),             // 18: trident.ci.v1.VolumeMount
+	(*ShellCfg)(nil),                // 19: trident.ci.v1.ShellCfg
+	(*CacheCfg)(nil),                // 20: trident.ci.v1.CacheCfg
+	(*DockerBuildCfg)(nil),          // 21: trident.ci.v1.DockerBuildCfg
+	(*LuaCfg)(nil),                  // 22: trident.ci.v1.LuaCfg
+	(*CurlCfg)(nil),                 // 23: trident.ci.v1.CurlCfg
+	(*Condition)(nil),               // 24: trident.ci.v1.Condition
+	(*FlowAttempt)(nil),             // 25: trident.ci.v1.FlowAttempt
+	(*FlowProgress)(nil),            // 26: trident.ci.v1.FlowProgress
+	(*StageProgress)(nil),           // 27: trident.ci.v1.StageProgress
+	(*PipelineProgress)(nil),        // 28: trident.ci.v1.PipelineProgress
+	(*BuildRequest)(nil),            // 29: trident.ci.v1.BuildRequest
+	(*BuildResponse)(nil),           // 30: trident.ci.v1.BuildResponse
+	(*GetBuildRequest)(nil),         // 31: trident.ci.v1.GetBuildRequest
+	(*BuildDetail)(nil),             // 32: trident.ci.v1.BuildDetail
+	(*ListBuildsRequest)(nil),       // 33: trident.ci.v1.ListBuildsRequest
+	(*ListBuildsResponse)(nil),      // 34: trident.ci.v1.ListBuildsResponse
+	(*DeleteBuildRequest)(nil),      // 35: trident.ci.v1.DeleteBuildRequest
+	(*StopBuildRequest)(nil),        // 36: trident.ci.v1.StopBuildRequest
+	(*EmptyResponse)(nil),           // 37: trident.ci.v1.EmptyResponse
+	(*Artifact)(nil),                // 38: trident.ci.v1.Artifact
+	(*ListArtifactsRequest)(nil),    // 39: trident.ci.v1.ListArtifactsRequest
+	(*ListArtifactsResponse)(nil),   // 40: trident.ci.v1.ListArtifactsResponse
+	(*DownloadArtifactRequest)(nil), // 41: trident.ci.v1.DownloadArtifactRequest
+	(*ArtifactChunk)(nil),           // 42: trident.ci.v1.ArtifactChunk
+	nil,                             // 43: trident.ci.v1.Pipeline.ParamsEntry
+	nil,                             // 44: trident.ci.v1.ShellCfg.TmpfsEntry
+	nil,                             // 45: trident.ci.v1.DockerBuildCfg.BuildArgsEntry
+	nil,                             // 46: trident.ci.v1.DockerBuildCfg.LabelsEntry
+	nil,                             // 47: trident.ci.v1.CurlCfg.ExtractEntry
+	nil,                             // 48: trident.ci.v1.PipelineProgress.EnvEntry
 }
 var file_api_pb_v1_pipeline_proto_depIdxs = []int32{
-	10, // 0: trident.ci.v1.Pipeline.flows:type_name -> trident.ci.v1.Flow
-	27, // 1: trident.ci.v1.Pipeline.params:type_name -> trident.ci.v1.Pipeline.ParamsEntry
-	0,  // 2: trident.ci.v1.Flow.type:type_name -> trident.ci.v1.FlowType
-	12, // 3: trident.ci.v1.Flow.scmCfg:type_name -> trident.ci.v1.ScmCfg
-	13, // 4: trident.ci.v1.Flow.shellCfg:type_name -> trident.ci.v1.ShellCfg
-	14, // 5: trident.ci.v1.Flow.dockerBuildCfg:type_name -> trident.ci.v1.DockerBuildCfg
-	15, // 6: trident.ci.v1.Flow.luaCfg:type_name -> trident.ci.v1.LuaCfg
-	2,  // 7: trident.ci.v1.Credit.type:type_name -> trident.ci.v1.CreditType
-	1,  // 8: trident.ci.v1.ScmCfg.vcsType:type_name -> trident.ci.v1.VCSType
-	11, // 9: trident.ci.v1.ScmCfg.credit:type_name -> trident.ci.v1.Credit
-	3,  // 10: trident.ci.v1.ShellCfg.imagePullPolicy:type_name -> trident.ci.v1.ImagePullPolicy
-	6,  // 11: trident.ci.v1.CurlCfg.reqType:type_name -> trident.ci.v1.CurlCfg.RequestType
-	7,  // 12: trident.ci.v1.CurlCfg.reqContentType:type_name -> trident.ci.v1.CurlCfg.ContentType
-	7,  // 13: trident.ci.v1.CurlCfg.respContentType:type_name -> trident.ci.v1.CurlCfg.ContentType
-	8,  // 14: trident.ci.v1.Condition.compare:type_name -> trident.ci.v1.Condition.Compare
-	10, // 15: trident.ci.v1.FlowProgress.flow:type_name -> trident.ci.v1.Flow
-	5,  // 16: trident.ci.v1.FlowProgress.status:type_name -> trident.ci.v1.Status
-	9,  // 17: trident.ci.v1.PipelineProgress.pipeline:type_name -> trident.ci.v1.Pipeline
-	5,  // 18: trident.ci.v1.PipelineProgress.status:type_name -> trident.ci.v1.Status
-	18, // 19: trident.ci.v1.PipelineProgress.flowProgresses:type_name -> trident.ci.v1.FlowProgress
-	28, // 20: trident.ci.v1.PipelineProgress.env:type_name -> trident.ci.v1.PipelineProgress.EnvEntry
-	9,  // 21: trident.ci.v1.BuildRequest.pipeline:type_name -> trident.ci.v1.Pipeline
-	19, // 22: trident.ci.v1.BuildDetail.progress:type_name -> trident.ci.v1.PipelineProgress
-	20, // 23: trident.ci.v1.Build.Build:input_type -> trident.ci.v1.BuildRequest
-	22, // 24: trident.ci.v1.Build.GetBuildResult:input_type -> trident.ci.v1.GetBuildRequest
-	24, // 25: trident.ci.v1.Build.DeleteBuild:input_type -> trident.ci.v1.DeleteBuildRequest
-	25, // 26: trident.ci.v1.Build.StopBuild:input_type -> trident.ci.v1.StopBuildRequest
-	21, // 27: trident.ci.v1.Build.Build:output_type -> trident.ci.v1.BuildResponse
-	23, // 28: trident.ci.v1.Build.GetBuildResult:output_type -> trident.ci.v1.BuildDetail
-	26, // 29: trident.ci.v1.Build.DeleteBuild:output_type -> trident.ci.v1.EmptyResponse
-	26, // 30: trident.ci.v1.Build.StopBuild:output_type -> trident.ci.v1.EmptyResponse
-	27, // [27:31] is the sub-list for method output_type
-	23, // [23:27] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	14, // 0: trident.ci.v1.Pipeline.flows:type_name -> trident.ci.v1.Flow
+	43, // 1: trident.ci.v1.Pipeline.params:type_name -> trident.ci.v1.Pipeline.ParamsEntry
+	13, // 2: trident.ci.v1.Pipeline.registryCredits:type_name -> trident.ci.v1.RegistryCredit
+	12, // 3: trident.ci.v1.Pipeline.stages:type_name -> trident.ci.v1.Stage
+	14, // 4: trident.ci.v1.Pipeline.onSuccess:type_name -> trident.ci.v1.Flow
+	14, // 5: trident.ci.v1.Pipeline.onFailure:type_name -> trident.ci.v1.Flow
+	14, // 6: trident.ci.v1.Pipeline.finally:type_name -> trident.ci.v1.Flow
+	14, // 7: trident.ci.v1.Stage.flows:type_name -> trident.ci.v1.Flow
+	0,  // 8: trident.ci.v1.Flow.type:type_name -> trident.ci.v1.FlowType
+	17, // 9: trident.ci.v1.Flow.scmCfg:type_name -> trident.ci.v1.ScmCfg
+	19, // 10: trident.ci.v1.Flow.shellCfg:type_name -> trident.ci.v1.ShellCfg
+	21, // 11: trident.ci.v1.Flow.dockerBuildCfg:type_name -> trident.ci.v1.DockerBuildCfg
+	22, // 12: trident.ci.v1.Flow.luaCfg:type_name -> trident.ci.v1.LuaCfg
+	24, // 13: trident.ci.v1.Flow.conditions:type_name -> trident.ci.v1.Condition
+	6,  // 14: trident.ci.v1.Flow.conditionConnector:type_name -> trident.ci.v1.ConditionConnector
+	23, // 15: trident.ci.v1.Flow.curlCfg:type_name -> trident.ci.v1.CurlCfg
+	15, // 16: trident.ci.v1.Flow.retry:type_name -> trident.ci.v1.RetryCfg
+	1,  // 17: trident.ci.v1.RetryCfg.errorClasses:type_name -> trident.ci.v1.ErrorClass
+	3,  // 18: trident.ci.v1.Credit.type:type_name -> trident.ci.v1.CreditType
+	2,  // 19: trident.ci.v1.ScmCfg.vcsType:type_name -> trident.ci.v1.VCSType
+	16, // 20: trident.ci.v1.ScmCfg.credit:type_name -> trident.ci.v1.Credit
+	4,  // 21: trident.ci.v1.ScmCfg.refType:type_name -> trident.ci.v1.RefType
+	5,  // 22: trident.ci.v1.ShellCfg.imagePullPolicy:type_name -> trident.ci.v1.ImagePullPolicy
+	18, // 23: trident.ci.v1.ShellCfg.volumes:type_name -> trident.ci.v1.VolumeMount
+	44, // 24: trident.ci.v1.ShellCfg.tmpfs:type_name -> trident.ci.v1.ShellCfg.TmpfsEntry
+	20, // 25: trident.ci.v1.ShellCfg.caches:type_name -> trident.ci.v1.CacheCfg
+	45, // 26: trident.ci.v1.DockerBuildCfg.buildArgs:type_name -> trident.ci.v1.DockerBuildCfg.BuildArgsEntry
+	46, // 27: trident.ci.v1.DockerBuildCfg.labels:type_name -> trident.ci.v1.DockerBuildCfg.LabelsEntry
+	8,  // 28: trident.ci.v1.CurlCfg.reqType:type_name -> trident.ci.v1.CurlCfg.RequestType
+	9,  // 29: trident.ci.v1.CurlCfg.reqContentType:type_name -> trident.ci.v1.CurlCfg.ContentType
+	9,  // 30: trident.ci.v1.CurlCfg.respContentType:type_name -> trident.ci.v1.CurlCfg.ContentType
+	47, // 31: trident.ci.v1.CurlCfg.extract:type_name -> trident.ci.v1.CurlCfg.ExtractEntry
+	10, // 32: trident.ci.v1.Condition.compare:type_name -> trident.ci.v1.Condition.Compare
+	14, // 33: trident.ci.v1.FlowProgress.flow:type_name -> trident.ci.v1.Flow
+	7,  // 34: trident.ci.v1.FlowProgress.status:type_name -> trident.ci.v1.Status
+	25, // 35: trident.ci.v1.FlowProgress.attempts:type_name -> trident.ci.v1.FlowAttempt
+	7,  // 36: trident.ci.v1.StageProgress.status:type_name -> trident.ci.v1.Status
+	11, // 37: trident.ci.v1.PipelineProgress.pipeline:type_name -> trident.ci.v1.Pipeline
+	7,  // 38: trident.ci.v1.PipelineProgress.status:type_name -> trident.ci.v1.Status
+	26, // 39: trident.ci.v1.PipelineProgress.flowProgresses:type_name -> trident.ci.v1.FlowProgress
+	48, // 40: trident.ci.v1.PipelineProgress.env:type_name -> trident.ci.v1.PipelineProgress.EnvEntry
+	27, // 41: trident.ci.v1.PipelineProgress.stageProgresses:type_name -> trident.ci.v1.StageProgress
+	26, // 42: trident.ci.v1.PipelineProgress.onSuccessProgresses:type_name -> trident.ci.v1.FlowProgress
+	26, // 43: trident.ci.v1.PipelineProgress.onFailureProgresses:type_name -> trident.ci.v1.FlowProgress
+	26, // 44: trident.ci.v1.PipelineProgress.finallyProgresses:type_name -> trident.ci.v1.FlowProgress
+	11, // 45: trident.ci.v1.BuildRequest.pipeline:type_name -> trident.ci.v1.Pipeline
+	28, // 46: trident.ci.v1.BuildDetail.progress:type_name -> trident.ci.v1.PipelineProgress
+	28, // 47: trident.ci.v1.ListBuildsResponse.progresses:type_name -> trident.ci.v1.PipelineProgress
+	38, // 48: trident.ci.v1.ListArtifactsResponse.artifacts:type_name -> trident.ci.v1.Artifact
+	29, // 49: trident.ci.v1.Build.Build:input_type -> trident.ci.v1.BuildRequest
+	31, // 50: trident.ci.v1.Build.GetBuildResult:input_type -> trident.ci.v1.GetBuildRequest
+	33, // 51: trident.ci.v1.Build.ListBuilds:input_type -> trident.ci.v1.ListBuildsRequest
+	35, // 52: trident.ci.v1.Build.DeleteBuild:input_type -> trident.ci.v1.DeleteBuildRequest
+	36, // 53: trident.ci.v1.Build.StopBuild:input_type -> trident.ci.v1.StopBuildRequest
+	39, // 54: trident.ci.v1.Build.ListArtifacts:input_type -> trident.ci.v1.ListArtifactsRequest
+	41, // 55: trident.ci.v1.Build.DownloadArtifact:input_type -> trident.ci.v1.DownloadArtifactRequest
+	30, // 56: trident.ci.v1.Build.Build:output_type -> trident.ci.v1.BuildResponse
+	32, // 57: trident.ci.v1.Build.GetBuildResult:output_type -> trident.ci.v1.BuildDetail
+	34, // 58: trident.ci.v1.Build.ListBuilds:output_type -> trident.ci.v1.ListBuildsResponse
+	37, // 59: trident.ci.v1.Build.DeleteBuild:output_type -> trident.ci.v1.EmptyResponse
+	37, // 60: trident.ci.v1.Build.StopBuild:output_type -> trident.ci.v1.EmptyResponse
+	40, // 61: trident.ci.v1.Build.ListArtifacts:output_type -> trident.ci.v1.ListArtifactsResponse
+	42, // 62: trident.ci.v1.Build.DownloadArtifact:output_type -> trident.ci.v1.ArtifactChunk
+	56, // [56:63] is the sub-list for method output_type
+	49, // [49:56] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_api_pb_v1_pipeline_proto_init() }
@@ -2020,7 +3801,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Flow); i {
+			switch v := v.(*Stage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2032,7 +3813,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Credit); i {
+			switch v := v.(*RegistryCredit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2044,7 +3825,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScmCfg); i {
+			switch v := v.(*Flow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2056,7 +3837,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShellCfg); i {
+			switch v := v.(*RetryCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2068,7 +3849,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DockerBuildCfg); i {
+			switch v := v.(*Credit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2080,7 +3861,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LuaCfg); i {
+			switch v := v.(*ScmCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2092,7 +3873,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CurlCfg); i {
+			switch v := v.(*VolumeMount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2104,7 +3885,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Condition); i {
+			switch v := v.(*ShellCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2116,7 +3897,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlowProgress); i {
+			switch v := v.(*CacheCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2128,7 +3909,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PipelineProgress); i {
+			switch v := v.(*DockerBuildCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2140,7 +3921,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildRequest); i {
+			switch v := v.(*LuaCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2152,7 +3933,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildResponse); i {
+			switch v := v.(*CurlCfg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2164,7 +3945,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBuildRequest); i {
+			switch v := v.(*Condition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2176,7 +3957,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BuildDetail); i {
+			switch v := v.(*FlowAttempt); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2188,7 +3969,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteBuildRequest); i {
+			switch v := v.(*FlowProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2200,7 +3981,7 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopBuildRequest); i {
+			switch v := v.(*StageProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2212,6 +3993,114 @@ func file_api_pb_v1_pipeline_proto_init() {
 			}
 		}
 		file_api_pb_v1_pipeline_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PipelineProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBuildsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBuildsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopBuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EmptyResponse); i {
 			case 0:
 				return &v.state
@@ -2223,14 +4112,74 @@ func file_api_pb_v1_pipeline_proto_init() {
 				return nil
 			}
 		}
+		file_api_pb_v1_pipeline_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Artifact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListArtifactsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListArtifactsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadArtifactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_pb_v1_pipeline_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArtifactChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_pb_v1_pipeline_proto_rawDesc,
-			NumEnums:      9,
-			NumMessages:   20,
+			NumEnums:      11,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2259,8 +4208,11 @@ const _ = grpc.SupportPackageIsVersion6
 type BuildClient interface {
 	Build(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (*BuildResponse, error)
 	GetBuildResult(ctx context.Context, in *GetBuildRequest, opts ...grpc.CallOption) (*BuildDetail, error)
+	ListBuilds(ctx context.Context, in *ListBuildsRequest, opts ...grpc.CallOption) (*ListBuildsResponse, error)
 	DeleteBuild(ctx context.Context, in *DeleteBuildRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	StopBuild(ctx context.Context, in *StopBuildRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (Build_DownloadArtifactClient, error)
 }
 
 type buildClient struct {
@@ -2289,6 +4241,15 @@ func (c *buildClient) GetBuildResult(ctx context.Context, in *GetBuildRequest, o
 	return out, nil
 }
 
+func (c *buildClient) ListBuilds(ctx context.Context, in *ListBuildsRequest, opts ...grpc.CallOption) (*ListBuildsResponse, error) {
+	out := new(ListBuildsResponse)
+	err := c.cc.Invoke(ctx, "/trident.ci.v1.Build/ListBuilds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *buildClient) DeleteBuild(ctx context.Context, in *DeleteBuildRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
 	err := c.cc.Invoke(ctx, "/trident.ci.v1.Build/DeleteBuild", in, out, opts...)
@@ -2307,12 +4268,56 @@ func (c *buildClient) StopBuild(ctx context.Context, in *StopBuildRequest, opts
 	return out, nil
 }
 
+func (c *buildClient) ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
+	out := new(ListArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/trident.ci.v1.Build/ListArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildClient) DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (Build_DownloadArtifactClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Build_serviceDesc.Streams[0], "/trident.ci.v1.Build/DownloadArtifact", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &buildDownloadArtifactClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Build_DownloadArtifactClient interface {
+	Recv() (*ArtifactChunk, error)
+	grpc.ClientStream
+}
+
+type buildDownloadArtifactClient struct {
+	grpc.ClientStream
+}
+
+func (x *buildDownloadArtifactClient) Recv() (*ArtifactChunk, error) {
+	m := new(ArtifactChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BuildServer is the server API for Build service.
 type BuildServer interface {
 	Build(context.Context, *BuildRequest) (*BuildResponse, error)
 	GetBuildResult(context.Context, *GetBuildRequest) (*BuildDetail, error)
+	ListBuilds(context.Context, *ListBuildsRequest) (*ListBuildsResponse, error)
 	DeleteBuild(context.Context, *DeleteBuildRequest) (*EmptyResponse, error)
 	StopBuild(context.Context, *StopBuildRequest) (*EmptyResponse, error)
+	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
+	DownloadArtifact(*DownloadArtifactRequest, Build_DownloadArtifactServer) error
 }
 
 // UnimplementedBuildServer can be embedded to have forward compatible implementations.
@@ -2325,12 +4330,21 @@ func (*UnimplementedBuildServer) Build(context.Context, *BuildRequest) (*BuildRe
 func (*UnimplementedBuildServer) GetBuildResult(context.Context, *GetBuildRequest) (*BuildDetail, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBuildResult not implemented")
 }
+func (*UnimplementedBuildServer) ListBuilds(context.Context, *ListBuildsRequest) (*ListBuildsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuilds not implemented")
+}
 func (*UnimplementedBuildServer) DeleteBuild(context.Context, *DeleteBuildRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBuild not implemented")
 }
 func (*UnimplementedBuildServer) StopBuild(context.Context, *StopBuildRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopBuild not implemented")
 }
+func (*UnimplementedBuildServer) ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
+}
+func (*UnimplementedBuildServer) DownloadArtifact(*DownloadArtifactRequest, Build_DownloadArtifactServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadArtifact not implemented")
+}
 
 func RegisterBuildServer(s *grpc.Server, srv BuildServer) {
 	s.RegisterService(&_Build_serviceDesc, srv)
@@ -2372,6 +4386,24 @@ func _Build_GetBuildResult_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Build_ListBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServer).ListBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trident.ci.v1.Build/ListBuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServer).ListBuilds(ctx, req.(*ListBuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Build_DeleteBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteBuildRequest)
 	if err := dec(in); err != nil {
@@ -2408,6 +4440,45 @@ func _Build_StopBuild_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Build_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trident.ci.v1.Build/ListArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServer).ListArtifacts(ctx, req.(*ListArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Build_DownloadArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BuildServer).DownloadArtifact(m, &buildDownloadArtifactServer{stream})
+}
+
+type Build_DownloadArtifactServer interface {
+	Send(*ArtifactChunk) error
+	grpc.ServerStream
+}
+
+type buildDownloadArtifactServer struct {
+	grpc.ServerStream
+}
+
+func (x *buildDownloadArtifactServer) Send(m *ArtifactChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Build_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "trident.ci.v1.Build",
 	HandlerType: (*BuildServer)(nil),
@@ -2420,6 +4491,10 @@ var _Build_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBuildResult",
 			Handler:    _Build_GetBuildResult_Handler,
 		},
+		{
+			MethodName: "ListBuilds",
+			Handler:    _Build_ListBuilds_Handler,
+		},
 		{
 			MethodName: "DeleteBuild",
 			Handler:    _Build_DeleteBuild_Handler,
@@ -2428,7 +4503,17 @@ var _Build_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopBuild",
 			Handler:    _Build_StopBuild_Handler,
 		},
+		{
+			MethodName: "ListArtifacts",
+			Handler:    _Build_ListArtifacts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadArtifact",
+			Handler:       _Build_DownloadArtifact_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/pb/v1/pipeline.proto",
 }
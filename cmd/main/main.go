@@ -4,29 +4,34 @@ import (
 	"context"
 	"flag"
 	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/config"
 	"github.com/skiwer/trident-ci/consumer"
 	"github.com/skiwer/trident-ci/log"
 	"github.com/skiwer/trident-ci/processor"
+	"github.com/skiwer/trident-ci/processor/curl"
 	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/dep_cache"
 	"github.com/skiwer/trident-ci/processor/docker_build"
 	"github.com/skiwer/trident-ci/processor/lua"
+	"github.com/skiwer/trident-ci/processor/registry"
 	"github.com/skiwer/trident-ci/processor/scm"
 	"github.com/skiwer/trident-ci/processor/shell"
 	"github.com/skiwer/trident-ci/queue"
 	rpc "github.com/skiwer/trident-ci/server/grpc"
 	"github.com/skiwer/trident-ci/server/web"
+	"github.com/skiwer/trident-ci/store"
 	"go.uber.org/zap"
 	"os"
+	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 )
 
 func main() {
-	flag.Parse()
-
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -39,6 +44,8 @@ func main() {
 		panic(err)
 	}
 
+	flag.Parse()
+
 	err = log.InitLogger(cfg.Env)
 
 	if err != nil {
@@ -58,14 +65,101 @@ func main() {
 		panic(err)
 	}
 
+	var scmCache *scm.MirrorCache
+
+	if cfg.ScmCacheEnabled {
+		// 从本地镜像clone时go-git需调用主机上的git-upload-pack
+		if _, err := exec.LookPath("git"); err != nil {
+			panic(errors.Wrap(err, "启用git镜像缓存需在主机上安装git"))
+		}
+
+		scmCache = scm.NewMirrorCache(filepath.Join(cfg.WorkDir, "scm-cache"), cfg.ScmCacheMaxSize*1024*1024, cfg.ScmCacheMaxAge)
+	}
+
+	registryStore, err := registry.LoadStore(cfg.RegistryCreditsFile)
+
+	if err != nil {
+		panic(err)
+	}
+
+	shellPolicy := &shell.Policy{
+		MaxCpus:         cfg.ShellMaxCpus,
+		MaxMemoryMb:     cfg.ShellMaxMemoryMb,
+		DefaultCpus:     cfg.ShellDefaultCpus,
+		DefaultMemoryMb: cfg.ShellDefaultMemoryMb,
+		AllowedNetworks: config.SplitList(cfg.ShellAllowedNetworks),
+		AllowedVolumes:  config.SplitList(cfg.ShellAllowedVolumes),
+		AllowedCaps:     config.SplitList(cfg.ShellAllowedCaps),
+		AllowedUsers:    config.SplitList(cfg.ShellAllowedUsers),
+		AllowExtraHosts: cfg.ShellAllowExtraHosts,
+		AllowPrivileged: cfg.ShellAllowPrivileged,
+		AllowHost:       cfg.ShellAllowHost,
+	}
+
+	var depCache *dep_cache.Cache
+
+	if cfg.ShellCacheType != "" {
+		shellCacheDir := cfg.ShellCacheDir
+
+		if shellCacheDir == "" {
+			shellCacheDir = filepath.Join(cfg.WorkDir, "shell-cache")
+		}
+
+		depCache, err = dep_cache.NewCache(dep_cache.Type(cfg.ShellCacheType), shellCacheDir, cfg.ShellCacheMaxSize*1024*1024, dockerCli)
+
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	dockerBuildRunner, err := docker_build.NewRunnerByBackend(docker_build.Backend(cfg.DockerBuildBackend), dockerCli, registryStore)
+
+	if err != nil {
+		panic(err)
+	}
+
 	flowRunnerMp := map[v1.FlowType]define.FlowRunner{
-		v1.FlowType_SCM:         scm.NewScmRunner(),
-		v1.FlowType_Shell:       shell.NewShellRunner(dockerCli),
-		v1.FlowType_DockerBuild: docker_build.NewDockerBuildRunner(dockerCli),
+		v1.FlowType_SCM:         scm.NewScmRunner(scmCache),
+		v1.FlowType_Shell:       shell.NewShellRunner(dockerCli, shellPolicy, registryStore, depCache),
+		v1.FlowType_DockerBuild: dockerBuildRunner,
 		v1.FlowType_Lua:         lua.NewLuaRunner(lua.NewLuaPool(cfg.MaxConcurrencyOfConsumer)),
+		v1.FlowType_Curl:        curl.NewCurlRunner(),
+	}
+
+	buildStorePath := cfg.BuildStorePath
+
+	if buildStorePath == "" {
+		buildStorePath = filepath.Join(cfg.WorkDir, "trident.db")
+	}
+
+	buildStore, err := store.NewStoreByType(store.Type(cfg.BuildStoreType), buildStorePath)
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer buildStore.Close()
+
+	timeouts := &processor.Timeouts{
+		Pipeline:    cfg.PipelineTimeout,
+		MaxPipeline: cfg.PipelineMaxTimeout,
+		Flow:        cfg.FlowTimeout,
+		MaxFlow:     cfg.FlowMaxTimeout,
+	}
+
+	pipelineProcessor := processor.NewPipelineProcessor(ctx, cfg.WorkDir, flowRunnerMp, buildStore, timeouts)
+
+	requeued, err := pipelineProcessor.Recover(ctx, cfg.RequeueInterruptedBuilds)
+
+	if err != nil {
+		panic(err)
 	}
 
-	pipelineProcessor := processor.NewPipelineProcessor(ctx, cfg.WorkDir, flowRunnerMp)
+	for _, pl := range requeued {
+		if err := q.Push(&queue.Message{ID: pl.Uid, Data: pl}); err != nil {
+			log.GetLogger().Error("被中断的构建重新入队失败", zap.Error(err), zap.String("pipelineId", pl.Uid))
+		}
+	}
 
 	wg.Add(1)
 	go func() {
@@ -94,7 +188,7 @@ func main() {
 		}
 	}()
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 	stopSig := <-c
 
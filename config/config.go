@@ -1,6 +1,10 @@
 package config
 
-import "flag"
+import (
+	"flag"
+	"strings"
+	"time"
+)
 
 type Config struct {
 	Env                      string
@@ -9,6 +13,32 @@ type Config struct {
 	WorkDir                  string
 	QueueType                string
 	MaxConcurrencyOfConsumer int
+	BuildStoreType           string
+	BuildStorePath           string
+	RequeueInterruptedBuilds bool
+	ScmCacheEnabled          bool
+	ScmCacheMaxSize          int64
+	ScmCacheMaxAge           time.Duration
+	ShellMaxCpus             float64
+	ShellMaxMemoryMb         int64
+	ShellDefaultCpus         float64
+	ShellDefaultMemoryMb     int64
+	ShellAllowedNetworks     string
+	ShellAllowedVolumes      string
+	ShellAllowedCaps         string
+	ShellAllowedUsers        string
+	ShellAllowExtraHosts     bool
+	ShellAllowPrivileged     bool
+	ShellAllowHost           bool
+	RegistryCreditsFile      string
+	DockerBuildBackend       string
+	ShellCacheType           string
+	ShellCacheDir            string
+	ShellCacheMaxSize        int64
+	PipelineTimeout          time.Duration
+	PipelineMaxTimeout       time.Duration
+	FlowTimeout              time.Duration
+	FlowMaxTimeout           time.Duration
 }
 
 type QueueConfig struct {
@@ -27,6 +57,42 @@ func (c *Config) Parse() error {
 	flag.StringVar(&c.WorkDir, "work-dir", "/tmp", "工作目录")
 	flag.StringVar(&c.QueueType, "queue-type", "channel", "消息队列类型")
 	flag.IntVar(&c.MaxConcurrencyOfConsumer, "max-concurrency-of-consumer", 5, "消费者最大并发处理任务数")
+	flag.StringVar(&c.BuildStoreType, "build-store-type", "bolt", "构建记录存储类型：memory为内存，bolt为本地文件")
+	flag.StringVar(&c.BuildStorePath, "build-store-path", "", "构建记录存储文件路径，为空时使用工作目录下的trident.db")
+	flag.BoolVar(&c.RequeueInterruptedBuilds, "requeue-interrupted-builds", false, "服务重启后是否将被中断的构建重新入队，否则标记为失败")
+	flag.BoolVar(&c.ScmCacheEnabled, "scm-cache", false, "是否在工作目录下缓存git镜像仓库，构建时从本地镜像clone，需在主机上安装git")
+	flag.Int64Var(&c.ScmCacheMaxSize, "scm-cache-max-size", 10240, "git镜像缓存总大小上限(MB)，为0时不限制")
+	flag.DurationVar(&c.ScmCacheMaxAge, "scm-cache-max-age", 7*24*time.Hour, "git镜像最大闲置时长，为0时不限制")
+	flag.Float64Var(&c.ShellMaxCpus, "shell-max-cpus", 0, "docker shell流程容器cpu核数上限，为0时不限制")
+	flag.Int64Var(&c.ShellMaxMemoryMb, "shell-max-memory", 0, "docker shell流程容器内存上限(MB)，为0时不限制")
+	flag.Float64Var(&c.ShellDefaultCpus, "shell-default-cpus", 0, "docker shell流程未指定时的容器cpu核数，为0时使用上限")
+	flag.Int64Var(&c.ShellDefaultMemoryMb, "shell-default-memory", 0, "docker shell流程未指定时的容器内存(MB)，为0时使用上限")
+	flag.StringVar(&c.ShellAllowedNetworks, "shell-allowed-networks", "bridge", "docker shell流程允许使用的网络模式，多个以逗号分隔")
+	flag.StringVar(&c.ShellAllowedVolumes, "shell-allowed-volumes", "", "docker shell流程允许挂载的主机路径（含子路径）及volume名，多个以逗号分隔")
+	flag.StringVar(&c.ShellAllowedCaps, "shell-allowed-caps", "", "docker shell流程允许添加的linux capabilities，多个以逗号分隔")
+	flag.StringVar(&c.ShellAllowedUsers, "shell-allowed-users", "", "docker shell流程允许使用的容器运行用户，如1000:1000，多个以逗号分隔")
+	flag.BoolVar(&c.ShellAllowExtraHosts, "shell-allow-extra-hosts", false, "是否允许docker shell流程添加额外的hosts记录")
+	flag.BoolVar(&c.ShellAllowPrivileged, "shell-allow-privileged", false, "是否允许docker shell流程使用特权模式")
+	flag.BoolVar(&c.ShellAllowHost, "shell-allow-host", false, "是否允许shell流程不使用docker直接在主机上执行，主机执行不受docker shell流程的各项限制")
+	flag.StringVar(&c.RegistryCreditsFile, "registry-credits-file", "", "镜像仓库凭证库json文件路径，流水线可按凭证名引用")
+	flag.StringVar(&c.ShellCacheType, "shell-cache-type", "", "docker shell流程依赖缓存存储方式：dir为主机目录，volume为docker volume，为空时不启用")
+	flag.StringVar(&c.ShellCacheDir, "shell-cache-dir", "", "依赖缓存主机目录，为空时使用工作目录下的shell-cache")
+	flag.Int64Var(&c.ShellCacheMaxSize, "shell-cache-max-size", 10240, "依赖缓存总大小上限(MB)，为0时不限制")
+	flag.DurationVar(&c.PipelineTimeout, "pipeline-timeout", time.Hour, "流水线未指定时的主流程超时时间")
+	flag.DurationVar(&c.PipelineMaxTimeout, "pipeline-max-timeout", 24*time.Hour, "流水线可指定的超时时间上限，为0时不限制")
+	flag.DurationVar(&c.FlowTimeout, "flow-timeout", 10*time.Minute, "流程未指定时的单次执行超时时间")
+	flag.DurationVar(&c.FlowMaxTimeout, "flow-max-timeout", 24*time.Hour, "流程可指定的超时时间上限，为0时不限制")
+	flag.StringVar(&c.DockerBuildBackend, "docker-build-backend", "docker", "镜像构建方式：docker为通过docker daemon构建，buildah为通过本地buildah命令构建")
 
 	return nil
 }
+
+// SplitList 解析以逗号分隔的配置项，忽略空白项
+func SplitList(s string) (list []string) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return
+}
@@ -4,8 +4,10 @@ go 1.16
 
 require (
 	github.com/containerd/containerd v1.5.4 // indirect
+	github.com/docker/distribution v2.7.1+incompatible
 	github.com/docker/docker v20.10.7+incompatible
 	github.com/docker/go-connections v0.4.0 // indirect
+	github.com/docker/go-units v0.4.0
 	github.com/gin-gonic/gin v1.7.2
 	github.com/go-git/go-git/v5 v5.4.2
 	github.com/golang/protobuf v1.5.2
@@ -17,6 +19,7 @@ require (
 	github.com/panjf2000/ants/v2 v2.4.6
 	github.com/pkg/errors v0.9.1
 	github.com/yuin/gopher-lua v0.0.0-20210529063254-f4c35e4016d9
+	go.etcd.io/bbolt v1.3.6
 	go.uber.org/zap v1.18.1
 	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac // indirect
 	google.golang.org/grpc v1.39.0
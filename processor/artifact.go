@@ -0,0 +1,181 @@
+package processor
+
+import (
+	"fmt"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"github.com/skiwer/trident-ci/processor/utils"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+var ErrArtifactNotFound = errors.New("构建产物不存在")
+
+func (p *PipeLineProcessor) getJobArtifactDir(dir string) string {
+	return fmt.Sprintf("%s/data/artifacts", dir)
+}
+
+// 构建产物目录内的路径，不允许通过..访问目录以外的文件
+func getArtifactPath(artifactDir, path string) string {
+	return filepath.Join(artifactDir, filepath.Clean("/"+path))
+}
+
+func validateArtifacts(patterns []string) error {
+	if _, err := fileutils.NewPatternMatcher(patterns); err != nil {
+		return errors.Wrapf(err, "构建产物路径格式错误")
+	}
+
+	return nil
+}
+
+// 将工作区中匹配patterns的文件复制到构建产物目录，保留相对路径，只收集普通文件，不跟随符号链接
+func (p *PipeLineProcessor) collectArtifacts(workDir, artifactDir string, patterns []string, jobLogger *logger.Logger) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	pm, err := fileutils.NewPatternMatcher(patterns)
+
+	if err != nil {
+		return errors.Wrapf(err, "构建产物路径格式错误")
+	}
+
+	var count int
+
+	err = filepath.Walk(workDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(workDir, path)
+
+		if err != nil {
+			return err
+		}
+
+		matched, err := pm.Matches(filepath.ToSlash(rel))
+
+		if err != nil || !matched {
+			return err
+		}
+
+		if err := copyFile(path, getArtifactPath(artifactDir, rel), info.Mode()); err != nil {
+			return errors.Wrapf(err, "复制构建产物[%s]失败", rel)
+		}
+
+		count++
+
+		return nil
+	})
+
+	if err != nil {
+		return errors.Wrapf(err, "收集构建产物失败")
+	}
+
+	jobLogger.Info("构建产物收集完成", zap.Strings("patterns", patterns), zap.Int("count", count))
+
+	return nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// ListArtifacts 查询流水线任务的构建产物
+func (p *PipeLineProcessor) ListArtifacts(pipelineId string) ([]*v1.Artifact, error) {
+	record, err := p.getPipelineRecord(pipelineId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	artifactDir := p.getJobArtifactDir(record.JobDir)
+	artifacts := []*v1.Artifact{}
+
+	if record.JobDir == "" || !utils.FileExists(artifactDir) {
+		return artifacts, nil
+	}
+
+	err = filepath.Walk(artifactDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		rel, err := filepath.Rel(artifactDir, path)
+
+		if err != nil {
+			return err
+		}
+
+		artifacts = append(artifacts, &v1.Artifact{
+			Path:    filepath.ToSlash(rel),
+			Size:    info.Size(),
+			ModTime: info.ModTime().UnixNano(),
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "构建产物读取失败")
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Path < artifacts[j].Path
+	})
+
+	return artifacts, nil
+}
+
+// GetArtifactFile 获取构建产物的本地文件路径
+func (p *PipeLineProcessor) GetArtifactFile(pipelineId, path string) (string, error) {
+	record, err := p.getPipelineRecord(pipelineId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if record.JobDir == "" {
+		return "", ErrArtifactNotFound
+	}
+
+	file := getArtifactPath(p.getJobArtifactDir(record.JobDir), path)
+
+	if info, err := os.Stat(file); err != nil || !info.Mode().IsRegular() {
+		return "", ErrArtifactNotFound
+	}
+
+	return file, nil
+}
@@ -1,18 +1,27 @@
 package build_context
 
 import (
+	"bufio"
 	"bytes"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/google/uuid"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 )
 
+// DockerIgnoreFile is the name of the file listing exclusion patterns for the build context
+const DockerIgnoreFile = ".dockerignore"
+
 // New creates a fake build context
 func New(dir string, modifiers ...func(*BuildContext) error) (*BuildContext, error) {
 	buildContext := &BuildContext{Dir: dir}
 	if dir == "" {
+		buildContext.temporary = true
 		if err := newDir(buildContext); err != nil {
 			return nil, err
 		}
@@ -46,9 +55,26 @@ func WithFile(name, content string) func(*BuildContext) error {
 	}
 }
 
-// WithDockerfile adds the specified content as Dockerfile in the build context
+// WithDockerfile adds the specified content as Dockerfile in the build context, under a unique
+// name so that an existing Dockerfile in the directory is not overwritten
 func WithDockerfile(content string) func(*BuildContext) error {
-	return WithFile("Dockerfile", content)
+	return func(ctx *BuildContext) error {
+		name := ".Dockerfile." + uuid.NewString()
+		if err := ctx.Add(name, content); err != nil {
+			return err
+		}
+		ctx.Dockerfile = name
+		return nil
+	}
+}
+
+// WithDockerfilePath sets the path (relative to the build context) of an existing Dockerfile,
+// so that it is kept in the tar archive even if .dockerignore excludes it
+func WithDockerfilePath(path string) func(*BuildContext) error {
+	return func(ctx *BuildContext) error {
+		ctx.Dockerfile = path
+		return nil
+	}
 }
 
 // WithFiles adds the specified files in the build context, content is a string
@@ -78,11 +104,22 @@ func WithBinaryFiles(files map[string]*bytes.Buffer) func(*BuildContext) error {
 // BuildContext creates directories that can be used as a build context
 type BuildContext struct {
 	Dir string
+	// Dockerfile is the path of the Dockerfile relative to Dir, either injected or set by
+	// WithDockerfilePath, empty means the default Dockerfile
+	Dockerfile string
+
+	temporary bool
+	added     []string
+	size      int64
 }
 
 // Add a file at a path, creating directories where necessary
 func (f *BuildContext) Add(file, content string) error {
-	return f.addFile(file, []byte(content))
+	if err := f.addFile(file, []byte(content)); err != nil {
+		return err
+	}
+	f.added = append(f.added, file)
+	return nil
 }
 
 func (f *BuildContext) addFile(file string, content []byte) error {
@@ -103,16 +140,111 @@ func (f *BuildContext) Delete(file string) error {
 	return os.RemoveAll(fp)
 }
 
-// Close deletes the context
+// Close deletes the context if it was created in a temporary directory, otherwise only
+// the files added to the existing directory are deleted
 func (f *BuildContext) Close() error {
-	return os.RemoveAll(f.Dir)
+	if f.temporary {
+		return os.RemoveAll(f.Dir)
+	}
+	for _, file := range f.added {
+		if err := f.Delete(file); err != nil {
+			return err
+		}
+	}
+	f.added = nil
+	return nil
+}
+
+// Size returns the number of bytes of the tar archive read so far
+func (f *BuildContext) Size() int64 {
+	return atomic.LoadInt64(&f.size)
 }
 
-// AsTarReader returns a ReadCloser with the contents of Dir as a tar archive.
+// AsTarReader returns a ReadCloser with the contents of Dir as a tar archive,
+// excluding the files matched by the patterns in .dockerignore.
 func (f *BuildContext) AsTarReader() (io.ReadCloser, error) {
-	reader, err := archive.TarWithOptions(f.Dir, &archive.TarOptions{})
+	excludes, err := f.readExcludes()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := archive.TarWithOptions(f.Dir, &archive.TarOptions{
+		ExcludePatterns: excludes,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return reader, nil
+	atomic.StoreInt64(&f.size, 0)
+	return &countingReader{ReadCloser: reader, size: &f.size}, nil
+}
+
+// readExcludes reads the exclusion patterns of .dockerignore in Dir. Like the docker cli,
+// the Dockerfile and .dockerignore themselves are always sent to the daemon.
+func (f *BuildContext) readExcludes() ([]string, error) {
+	file, err := os.Open(filepath.Join(f.Dir, DockerIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	excludes, err := readPatterns(file)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerfile := f.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	for _, keep := range []string{dockerfile, DockerIgnoreFile} {
+		if excluded, _ := fileutils.Matches(keep, excludes); excluded {
+			excludes = append(excludes, "!"+keep)
+		}
+	}
+
+	return excludes, nil
+}
+
+// readPatterns parses .dockerignore content, skipping comments and empty lines
+func readPatterns(reader io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		invert := strings.HasPrefix(pattern, "!")
+		if invert {
+			pattern = strings.TrimSpace(pattern[1:])
+		}
+		if len(pattern) > 0 {
+			pattern = filepath.ToSlash(filepath.Clean(pattern))
+			if len(pattern) > 1 && pattern[0] == '/' {
+				pattern = pattern[1:]
+			}
+		}
+		if invert {
+			pattern = "!" + pattern
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+type countingReader struct {
+	io.ReadCloser
+	size *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	atomic.AddInt64(r.size, int64(n))
+	return n, err
 }
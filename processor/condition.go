@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"strconv"
+	"strings"
+)
+
+func validateConditions(flow *v1.Flow) error {
+	for _, c := range flow.Conditions {
+		if c.Key == "" {
+			return errors.New("执行条件的key不能为空")
+		}
+
+		if _, ok := v1.Condition_Compare_name[int32(c.Compare)]; !ok {
+			return fmt.Errorf("执行条件[%s]的比较方式未知", c.Key)
+		}
+	}
+
+	return nil
+}
+
+// 比较两个值，均为数字时按数值比较，否则按字符串比较
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+func matchCondition(c *v1.Condition, noEnvRender bool, processCtx *define.ProcessCtx) bool {
+	value := processCtx.Env[c.Key]
+	target := c.Target
+
+	if !noEnvRender {
+		target = processCtx.RenderByEnv(target)
+	}
+
+	switch c.Compare {
+	case v1.Condition_Equal:
+		return value == target
+	case v1.Condition_Less:
+		return compareValues(value, target) < 0
+	case v1.Condition_More:
+		return compareValues(value, target) > 0
+	case v1.Condition_Contains:
+		return strings.Contains(value, target)
+	default:
+		return false
+	}
+}
+
+// 判断流程的执行条件是否满足，没有条件时总是执行
+func matchConditions(flow *v1.Flow, processCtx *define.ProcessCtx) bool {
+	if len(flow.Conditions) == 0 {
+		return true
+	}
+
+	for _, c := range flow.Conditions {
+		matched := matchCondition(c, flow.NoEnvRender, processCtx)
+
+		if flow.ConditionConnector == v1.ConditionConnector_Or && matched {
+			return true
+		}
+
+		if flow.ConditionConnector == v1.ConditionConnector_And && !matched {
+			return false
+		}
+	}
+
+	return flow.ConditionConnector == v1.ConditionConnector_And
+}
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// 流水线的全部流程，包括阶段内的流程及结束后执行的流程
+func getAllFlows(pl *v1.Pipeline) []*v1.Flow {
+	flows := append([]*v1.Flow{}, pl.Flows...)
+
+	for _, stage := range pl.Stages {
+		flows = append(flows, stage.Flows...)
+	}
+
+	return append(append(append(flows, pl.OnSuccess...), pl.OnFailure...), pl.Finally...)
+}
+
+func maskFlowCredentials(flow *v1.Flow) {
+	if flow == nil || flow.ScmCfg == nil || flow.ScmCfg.Credit == nil {
+		return
+	}
+
+	credit := flow.ScmCfg.Credit
+	credit.Username, credit.Password, credit.PrivateKey = "", "", ""
+}
+
+// 流水线中直接填写的镜像仓库凭证及代码拉取凭证只在执行时使用，持久化及对外返回的流水线进度去掉凭证内容
+func withoutCredentials(progress *v1.PipelineProgress) *v1.PipelineProgress {
+	masked := proto.Clone(progress).(*v1.PipelineProgress)
+
+	if pl := masked.Pipeline; pl != nil {
+		for _, credit := range pl.RegistryCredits {
+			credit.Username, credit.Password = "", ""
+		}
+
+		for _, flow := range getAllFlows(pl) {
+			maskFlowCredentials(flow)
+		}
+	}
+
+	for _, progresses := range [][]*v1.FlowProgress{masked.FlowProgresses, masked.OnSuccessProgresses, masked.OnFailureProgresses, masked.FinallyProgresses} {
+		for _, flowProgress := range progresses {
+			maskFlowCredentials(flowProgress.Flow)
+		}
+	}
+
+	return masked
+}
+
+// 流水线中直接填写的凭证不会持久化，重新入队后无法使用
+func hasInlineCredentials(pl *v1.Pipeline) bool {
+	for _, credit := range pl.RegistryCredits {
+		if credit.Name == "" {
+			return true
+		}
+	}
+
+	for _, flow := range getAllFlows(pl) {
+		if flow.ScmCfg != nil && flow.ScmCfg.Credit != nil && flow.ScmCfg.Credit.Type != v1.CreditType_NoCredit {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,253 @@
+package curl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 日志中记录的请求及响应body最大长度
+const maxLoggedBodySize = 4096
+
+// 读取的响应body最大长度
+const maxRespBodySize = 10 * 1024 * 1024
+
+var contentTypes = map[v1.CurlCfg_ContentType]string{
+	v1.CurlCfg_JSON:  "application/json",
+	v1.CurlCfg_Form:  "application/x-www-form-urlencoded",
+	v1.CurlCfg_Plain: "text/plain",
+	v1.CurlCfg_Xml:   "application/xml",
+}
+
+type Runner struct {
+	client *http.Client
+}
+
+func NewCurlRunner() *Runner {
+	return &Runner{
+		client: &http.Client{},
+	}
+}
+
+func (r *Runner) Validate(flowCfg *v1.Flow) error {
+	cfg := flowCfg.CurlCfg
+
+	if cfg == nil {
+		return errors.New("curl流程配置不能为空")
+	}
+
+	if cfg.Url == "" {
+		return errors.New("请求地址不能为空")
+	}
+
+	if _, ok := v1.CurlCfg_RequestType_name[int32(cfg.ReqType)]; !ok {
+		return errors.New("请求方法未知")
+	}
+
+	if cfg.Timeout != "" {
+		if _, err := time.ParseDuration(cfg.Timeout); err != nil {
+			return errors.Wrapf(err, "请求超时时间[%s]格式错误", cfg.Timeout)
+		}
+	}
+
+	if len(cfg.Extract) > 0 && cfg.RespContentType != v1.CurlCfg_JSON && cfg.RespContentType != v1.CurlCfg_Xml {
+		return errors.New("仅支持从json或xml响应中提取字段")
+	}
+
+	for name, path := range cfg.Extract {
+		var err error
+
+		if cfg.RespContentType == v1.CurlCfg_JSON {
+			_, err = parseJSONPath(path)
+		} else {
+			_, err = parseXPath(path)
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "环境变量[%s]的提取路径错误", name)
+		}
+	}
+
+	return nil
+}
+
+// 渲染后的请求参数，渲染结果不写回流程配置，重试时重新按最新的环境变量渲染
+type request struct {
+	url            string
+	postData       string
+	extraReqHeader string
+	timeout        string
+}
+
+func (r *Runner) renderRequest(flowCfg *v1.Flow, processCtx *define.ProcessCtx) *request {
+	cfg := flowCfg.CurlCfg
+
+	if flowCfg.NoEnvRender {
+		return &request{url: cfg.Url, postData: cfg.PostData, extraReqHeader: cfg.ExtraReqHeader, timeout: cfg.Timeout}
+	}
+
+	return &request{
+		url:            processCtx.RenderByEnv(cfg.Url),
+		postData:       processCtx.RenderByEnv(cfg.PostData),
+		extraReqHeader: processCtx.RenderByEnv(cfg.ExtraReqHeader),
+		timeout:        processCtx.RenderByEnv(cfg.Timeout),
+	}
+}
+
+// 解析额外的请求头，每行一个，格式为Key: Value
+func parseHeader(extraReqHeader string) (http.Header, error) {
+	header := http.Header{}
+	scanner := bufio.NewScanner(strings.NewReader(extraReqHeader))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		kv := strings.SplitN(line, ":", 2)
+
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("请求头[%s]格式错误", line)
+		}
+
+		header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+
+	return header, nil
+}
+
+func truncate(s string) string {
+	if len(s) <= maxLoggedBodySize {
+		return s
+	}
+	return s[:maxLoggedBodySize] + "...(已截断)"
+}
+
+func isFailStatus(cfg *v1.CurlCfg, statusCode int) bool {
+	if len(cfg.FailStatusCodes) == 0 {
+		return statusCode >= http.StatusBadRequest
+	}
+
+	for _, code := range cfg.FailStatusCodes {
+		if int(code) == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *define.ProcessCtx, logger *logger.Logger) error {
+	cfg := flowCfg.CurlCfg
+	rendered := r.renderRequest(flowCfg, processCtx)
+
+	if rendered.timeout != "" {
+		timeout, err := time.ParseDuration(rendered.timeout)
+
+		if err != nil {
+			return errors.Wrapf(err, "请求超时时间[%s]格式错误", rendered.timeout)
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	header, err := parseHeader(rendered.extraReqHeader)
+
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+
+	if rendered.postData != "" {
+		body = strings.NewReader(rendered.postData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, cfg.ReqType.String(), rendered.url, body)
+
+	if err != nil {
+		return errors.Wrapf(err, "请求创建失败")
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentTypes[cfg.ReqContentType])
+	}
+
+	for k, values := range header {
+		req.Header[k] = values
+	}
+
+	// 请求头可能包含凭证，只记录名称
+	headerNames := make([]string, 0, len(req.Header))
+
+	for k := range req.Header {
+		headerNames = append(headerNames, k)
+	}
+
+	logger.Info("发送http请求",
+		zap.String("method", req.Method),
+		zap.String("url", rendered.url),
+		zap.Strings("headers", headerNames),
+		zap.String("body", truncate(rendered.postData)),
+	)
+
+	resp, err := r.client.Do(req)
+
+	if err != nil {
+		return errors.Wrapf(err, "http请求失败")
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRespBodySize))
+
+	if err != nil {
+		return errors.Wrapf(err, "读取http响应失败")
+	}
+
+	logger.Info("收到http响应",
+		zap.Int("status", resp.StatusCode),
+		zap.String("contentType", resp.Header.Get("Content-Type")),
+		zap.String("body", truncate(string(respBody))),
+	)
+
+	if isFailStatus(cfg, resp.StatusCode) {
+		return fmt.Errorf("http响应状态码[%d]视为失败", resp.StatusCode)
+	}
+
+	env := make(map[string]string, len(cfg.Extract))
+
+	for name, path := range cfg.Extract {
+		var value string
+
+		if cfg.RespContentType == v1.CurlCfg_Xml {
+			value, err = extractXML(respBody, path)
+		} else {
+			value, err = extractJSON(respBody, path)
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "提取环境变量[%s]失败", name)
+		}
+
+		env[name] = value
+	}
+
+	processCtx.AppendEnv(env)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package curl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPath中的一级，key或数组下标
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// 解析JSONPath，支持$.a.b、$['a']及$.a[0]形式，下标为负数时从数组末尾开始计算
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath[%s]需以$开头", path)
+	}
+
+	var steps []jsonPathStep
+	rest := path[1:]
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("JSONPath[%s]格式错误", path)
+			}
+
+			steps = append(steps, jsonPathStep{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath[%s]格式错误", path)
+			}
+
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1]})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath[%s]不支持[%s]", path, inner)
+			}
+
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+		default:
+			return nil, fmt.Errorf("JSONPath[%s]格式错误", path)
+		}
+	}
+
+	return steps, nil
+}
+
+// 按JSONPath提取json中的值，字符串直接返回，对象及数组返回json
+func extractJSON(data []byte, path string) (string, error) {
+	steps, err := parseJSONPath(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("响应不是合法的json: %s", err.Error())
+	}
+
+	for _, step := range steps {
+		if step.isIndex {
+			arr, ok := v.([]interface{})
+
+			if !ok {
+				return "", fmt.Errorf("JSONPath[%s]对应的值不是数组", path)
+			}
+
+			index := step.index
+
+			if index < 0 {
+				index += len(arr)
+			}
+
+			if index < 0 || index >= len(arr) {
+				return "", fmt.Errorf("JSONPath[%s]数组下标越界", path)
+			}
+
+			v = arr[index]
+			continue
+		}
+
+		obj, ok := v.(map[string]interface{})
+
+		if !ok {
+			return "", fmt.Errorf("JSONPath[%s]对应的值不是对象", path)
+		}
+
+		if v, ok = obj[step.key]; !ok {
+			return "", fmt.Errorf("JSONPath[%s]对应的字段[%s]不存在", path, step.key)
+		}
+	}
+
+	switch value := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		out, err := json.Marshal(value)
+		return string(out), err
+	}
+}
@@ -0,0 +1,61 @@
+package curl
+
+import (
+	"testing"
+)
+
+func TestExtractJSON(t *testing.T) {
+	data := []byte(`{"a":{"b":"x","n":1.50,"ok":true,"null":null},"list":[{"id":1},{"id":2}],"k.e y":"v"}`)
+
+	cases := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "$.a.b", want: "x"},
+		{path: "$['a']['b']", want: "x"},
+		{path: `$["k.e y"]`, want: "v"},
+		{path: "$.a.n", want: "1.50"},
+		{path: "$.a.ok", want: "true"},
+		{path: "$.a.null", want: ""},
+		{path: "$.list[0].id", want: "1"},
+		{path: "$.list[-1].id", want: "2"},
+		{path: "$.list[1]", want: `{"id":2}`},
+		{path: "$.a.missing", wantErr: true},
+		{path: "$.list[2]", wantErr: true},
+		{path: "$.list[-3]", wantErr: true},
+		{path: "$.a[0]", wantErr: true},
+		{path: "$.list.id", wantErr: true},
+		{path: "a.b", wantErr: true},
+		{path: "$..a", wantErr: true},
+		{path: "$.a[*]", wantErr: true},
+		{path: "$.a[0", wantErr: true},
+		{path: "$a", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := extractJSON(data, c.path)
+
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("extractJSON(%q) = %q, want error", c.path, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("extractJSON(%q) error: %v", c.path, err)
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("extractJSON(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestExtractJSONInvalidData(t *testing.T) {
+	if _, err := extractJSON([]byte(`{"a":`), "$.a"); err == nil {
+		t.Error("extractJSON with invalid json should fail")
+	}
+}
@@ -0,0 +1,208 @@
+package curl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	children []*xmlNode
+	text     strings.Builder
+}
+
+// 节点的文本内容，包含全部子孙节点的文本
+func (n *xmlNode) textContent() string {
+	var sb strings.Builder
+	sb.WriteString(n.text.String())
+
+	for _, child := range n.children {
+		sb.WriteString(child.textContent())
+	}
+
+	return sb.String()
+}
+
+func (n *xmlNode) descendants(name string, out []*xmlNode) []*xmlNode {
+	for _, child := range n.children {
+		if name == "*" || child.name == name {
+			out = append(out, child)
+		}
+		out = child.descendants(name, out)
+	}
+	return out
+}
+
+func parseXML(data []byte) (*xmlNode, error) {
+	doc := &xmlNode{}
+	stack := []*xmlNode{doc}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		tok, err := dec.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("响应不是合法的xml: %s", err.Error())
+		}
+
+		cur := stack[len(stack)-1]
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local, attrs: map[string]string{}}
+
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+
+			cur.children = append(cur.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			cur.text.Write(t)
+		}
+	}
+
+	return doc, nil
+}
+
+// XPath中的一级，元素名及可选的下标（从1开始）
+type xpathStep struct {
+	name  string
+	index int
+}
+
+type xpath struct {
+	steps []xpathStep
+	// 以//开头时第一级匹配任意层级的元素
+	descendant bool
+	// 以/@attr结尾时取属性
+	attr string
+}
+
+// 解析XPath，支持/a/b[1]、//b形式的元素路径，可以/@attr或/text()结尾
+func parseXPath(path string) (*xpath, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("XPath[%s]需以/开头", path)
+	}
+
+	ret := &xpath{descendant: strings.HasPrefix(path, "//")}
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	if ret.descendant {
+		parts = parts[1:]
+	}
+
+	if last := parts[len(parts)-1]; strings.HasPrefix(last, "@") {
+		ret.attr = last[1:]
+		parts = parts[:len(parts)-1]
+
+		if ret.attr == "" {
+			return nil, fmt.Errorf("XPath[%s]格式错误", path)
+		}
+	} else if last == "text()" {
+		parts = parts[:len(parts)-1]
+	}
+
+	for _, part := range parts {
+		step := xpathStep{name: part}
+
+		if i := strings.Index(part, "["); i >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("XPath[%s]格式错误", path)
+			}
+
+			index, err := strconv.Atoi(part[i+1 : len(part)-1])
+
+			if err != nil || index < 1 {
+				return nil, fmt.Errorf("XPath[%s]不支持[%s]", path, part[i:])
+			}
+
+			step = xpathStep{name: part[:i], index: index}
+		}
+
+		if step.name == "" || strings.ContainsAny(step.name, "[]@()") {
+			return nil, fmt.Errorf("XPath[%s]格式错误", path)
+		}
+
+		ret.steps = append(ret.steps, step)
+	}
+
+	if len(ret.steps) == 0 {
+		return nil, fmt.Errorf("XPath[%s]格式错误", path)
+	}
+
+	return ret, nil
+}
+
+// 按XPath提取xml中的值，以/@attr结尾时取属性，否则取元素文本
+func extractXML(data []byte, path string) (string, error) {
+	xp, err := parseXPath(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := parseXML(data)
+
+	if err != nil {
+		return "", err
+	}
+
+	nodes := []*xmlNode{doc}
+
+	for i, step := range xp.steps {
+		var next []*xmlNode
+
+		for _, node := range nodes {
+			var matched []*xmlNode
+
+			if i == 0 && xp.descendant {
+				matched = node.descendants(step.name, nil)
+			} else {
+				for _, child := range node.children {
+					if step.name == "*" || child.name == step.name {
+						matched = append(matched, child)
+					}
+				}
+			}
+
+			if step.index > 0 {
+				if step.index > len(matched) {
+					continue
+				}
+				matched = matched[step.index-1 : step.index]
+			}
+
+			next = append(next, matched...)
+		}
+
+		nodes = next
+	}
+
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("XPath[%s]没有匹配的元素", path)
+	}
+
+	if xp.attr == "" {
+		return strings.TrimSpace(nodes[0].textContent()), nil
+	}
+
+	value, ok := nodes[0].attrs[xp.attr]
+
+	if !ok {
+		return "", fmt.Errorf("XPath[%s]对应的属性不存在", path)
+	}
+
+	return value, nil
+}
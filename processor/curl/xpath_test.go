@@ -0,0 +1,85 @@
+package curl
+
+import (
+	"testing"
+)
+
+func TestExtractXML(t *testing.T) {
+	data := []byte(`<root><item id="1">a</item><item id="2">b<sub>c</sub></item><group><item id="3">d</item></group></root>`)
+
+	cases := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "/root/item", want: "a"},
+		{path: "/root/item[2]", want: "bc"},
+		{path: "/root/item[2]/@id", want: "2"},
+		{path: "/root/item[1]/text()", want: "a"},
+		{path: "/root/*[3]/item", want: "d"},
+		{path: "//item[3]/@id", want: "3"},
+		{path: "//group/item", want: "d"},
+		{path: "/root/item[4]", wantErr: true},
+		{path: "/root/item/@missing", wantErr: true},
+		{path: "/root/missing", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := extractXML(data, c.path)
+
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("extractXML(%q) = %q, want error", c.path, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("extractXML(%q) error: %v", c.path, err)
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("extractXML(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestParseXPath(t *testing.T) {
+	cases := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "/a"},
+		{path: "/a/b[1]/@c"},
+		{path: "//a/text()"},
+		{path: "/*"},
+		{path: "", wantErr: true},
+		{path: "a/b", wantErr: true},
+		{path: "/", wantErr: true},
+		{path: "//", wantErr: true},
+		{path: "///a", wantErr: true},
+		{path: "//a//b", wantErr: true},
+		{path: "/a/", wantErr: true},
+		{path: "/@id", wantErr: true},
+		{path: "/a/@", wantErr: true},
+		{path: "/a[0]", wantErr: true},
+		{path: "/a[x]", wantErr: true},
+		{path: "/a[1", wantErr: true},
+		{path: "/a[1]b", wantErr: true},
+		{path: "/a[@id='1']", wantErr: true},
+		{path: "/a/count()", wantErr: true},
+	}
+
+	for _, c := range cases {
+		_, err := parseXPath(c.path)
+
+		if c.wantErr && err == nil {
+			t.Errorf("parseXPath(%q) want error", c.path)
+		}
+
+		if !c.wantErr && err != nil {
+			t.Errorf("parseXPath(%q) error: %v", c.path, err)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 获取流程依赖关系，deps[i]为流程i依赖的流程索引
+// 流水线中没有任何流程声明dependsOn时，每个流程依赖前一个流程，即按顺序依次执行
+func getFlowDeps(flows []*v1.Flow) ([][]int, error) {
+	deps := make([][]int, len(flows))
+	dag := false
+
+	for _, flow := range flows {
+		if len(flow.DependsOn) > 0 {
+			dag = true
+			break
+		}
+	}
+
+	if !dag {
+		for idx := 1; idx < len(flows); idx++ {
+			deps[idx] = []int{idx - 1}
+		}
+		return deps, nil
+	}
+
+	uidIdx := make(map[string]int, len(flows))
+
+	for idx, flow := range flows {
+		if flow.Uid == "" {
+			continue
+		}
+
+		if _, ok := uidIdx[flow.Uid]; ok {
+			return nil, fmt.Errorf("流程uid[%s]重复", flow.Uid)
+		}
+
+		uidIdx[flow.Uid] = idx
+	}
+
+	for idx, flow := range flows {
+		for _, uid := range flow.DependsOn {
+			dep, ok := uidIdx[uid]
+
+			if !ok {
+				return nil, fmt.Errorf("流程[索引=%d]依赖的流程[%s]不存在", idx, uid)
+			}
+
+			deps[idx] = append(deps[idx], dep)
+		}
+	}
+
+	if cycle := findCycle(deps); cycle != nil {
+		path := make([]string, 0, len(cycle))
+
+		for _, idx := range cycle {
+			path = append(path, strconv.Itoa(idx))
+		}
+
+		return nil, fmt.Errorf("流程依赖存在循环: 索引%s", strings.Join(path, " -> "))
+	}
+
+	return deps, nil
+}
+
+// 深度优先查找依赖环，返回环上的流程索引，首尾相同；不存在环时返回nil
+func findCycle(deps [][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(deps))
+	var stack []int
+	var cycle []int
+
+	var visit func(idx int) bool
+
+	visit = func(idx int) bool {
+		state[idx] = visiting
+		stack = append(stack, idx)
+
+		for _, dep := range deps[idx] {
+			switch state[dep] {
+			case visiting:
+				for i, v := range stack {
+					if v == dep {
+						cycle = append(append([]int{}, stack[i:]...), dep)
+						break
+					}
+				}
+				return true
+			case unvisited:
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[idx] = visited
+
+		return false
+	}
+
+	for idx := range deps {
+		if state[idx] == unvisited && visit(idx) {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+type flowResult struct {
+	idx        int
+	err        error
+	attempts   []*v1.FlowAttempt
+	processCtx *define.ProcessCtx
+}
+
+// 按依赖关系调度执行流程，依赖全部成功的流程立即并行执行
+// 执行条件不满足的流程标记为Skipped，允许失败的流程失败后标记为FailedAllowed，均视同成功，不阻塞依赖它的流程
+// 流程失败后默认取消正在运行的其他流程并停止调度，未执行的流程保持Created状态；
+// 流水线被取消或超时时，未执行的流程标记为Canceled或TimedOut
+func (p *PipeLineProcessor) runFlows(jobCtx context.Context, job *v1.Pipeline, flows []*v1.Flow, deps [][]int, jobWorkDir, artifactDir string, processCtx *define.ProcessCtx, jobLogger *logger.Logger, runEntity PipelineRunEntity) {
+	dagCtx, dagCancel := context.WithCancel(jobCtx)
+	defer dagCancel()
+
+	progresses := runEntity.Progress.FlowProgresses
+	started := make([]bool, len(flows))
+	resultCh := make(chan flowResult)
+	running := 0
+
+	// 并行的流程可能匹配到相同的构建产物，串行收集避免同时写入同一文件
+	var artifactMu sync.Mutex
+
+	depsSucceed := func(idx int) bool {
+		for _, dep := range deps[idx] {
+			switch progresses[dep].Status {
+			case v1.Status_Succeed, v1.Status_Skipped, v1.Status_FailedAllowed:
+			default:
+				return false
+			}
+		}
+		return true
+	}
+
+	// 跳过的流程可能使依赖它的流程满足执行条件，需要重复调度直到没有新的流程开始
+	var schedule func()
+
+	schedule = func() {
+		skipped := false
+
+		for idx, flow := range flows {
+			if started[idx] || !depsSucceed(idx) {
+				continue
+			}
+
+			started[idx] = true
+
+			if !matchConditions(flow, processCtx) {
+				now := time.Now().UnixNano()
+				progresses[idx].Status = v1.Status_Skipped
+				progresses[idx].StartTime = now
+				progresses[idx].FinishTime = now
+				jobLogger.Info("流程执行条件不满足，跳过执行", zap.Int("flowIndex", idx), zap.String("flowType", flow.Type.String()))
+				skipped = true
+				continue
+			}
+
+			running++
+
+			progresses[idx].Status = v1.Status_Running
+			progresses[idx].StartTime = time.Now().UnixNano()
+			runEntity.Progress.CurRunningFlowId = flow.Uid
+
+			go func(idx int, flow *v1.Flow, flowProcessCtx *define.ProcessCtx) {
+				flowLogger := jobLogger.With(zap.Int("flowIndex", idx))
+
+				attempts, err := p.runFlowWithRetry(dagCtx, idx, flow, jobWorkDir, flowProcessCtx, flowLogger)
+
+				// 流程失败时也收集构建产物，以便查看测试报告等
+				artifactMu.Lock()
+				artifactErr := p.collectArtifacts(jobWorkDir, artifactDir, flow.Artifacts, flowLogger)
+				artifactMu.Unlock()
+
+				if artifactErr != nil && err == nil {
+					err = artifactErr
+				}
+
+				resultCh <- flowResult{idx: idx, err: err, attempts: attempts, processCtx: flowProcessCtx}
+			}(idx, flow, processCtx.Fork())
+		}
+
+		if skipped {
+			schedule()
+			return
+		}
+
+		p.updatePipelineRunEntity(job.Uid, runEntity)
+	}
+
+	schedule()
+
+	for running > 0 {
+		res := <-resultCh
+		running--
+
+		idx, flow := res.idx, flows[res.idx]
+
+		progresses[idx].Attempts = res.attempts
+
+		stop := false
+
+		flowError := res.err
+
+		// 只有流程自身设置的构建状态才作为该流程的结果，设置后不合并到流水线上下文，
+		// 避免允许失败的流程调用fail后，之后的流程都被判定为失败
+		if flowError == nil && res.processCtx.StatusChanged() {
+			if res.processCtx.PipelineFailed() {
+				flowError = errors.New(res.processCtx.GetFailReason())
+			} else if res.processCtx.PipelineSucceed() {
+				stop = true
+			}
+		}
+
+		res.processCtx.RestoreStatus()
+		processCtx.Merge(res.processCtx)
+
+		// 流水线整体超时时，被中断的流程同样标记为超时
+		pipelineTimedOut := flowError != nil && jobCtx.Err() == context.DeadlineExceeded
+		var flowTimeout *flowTimeoutError
+
+		if flowError != nil && flow.ContinueOnError && !errors.Is(flowError, context.Canceled) && !pipelineTimedOut {
+			progresses[idx].Status = v1.Status_FailedAllowed
+			progresses[idx].FailReason = flowError.Error()
+			jobLogger.Warn("允许失败的流程执行失败，继续执行", zap.Int("flowIndex", idx), zap.String("error", flowError.Error()))
+		} else if flowError != nil {
+			if pipelineTimedOut {
+				progresses[idx].Status = v1.Status_TimedOut
+				progresses[idx].FailReason = fmt.Sprintf("流水线执行超时(%s): %s", p.timeouts.getPipelineTimeout(job), flowError.Error())
+			} else if errors.Is(flowError, context.Canceled) {
+				progresses[idx].Status = v1.Status_Canceled
+				progresses[idx].FailReason = fmt.Sprintf("流程执行被取消: %s", flowError.Error())
+			} else {
+				progresses[idx].Status = v1.Status_Failed
+
+				if errors.As(flowError, &flowTimeout) {
+					progresses[idx].Status = v1.Status_TimedOut
+				}
+
+				progresses[idx].FailReason = flowError.Error()
+
+				if !job.KeepRunningOnFailure {
+					dagCancel()
+				}
+			}
+			log.GetLogger().Error("流程执行失败", zap.Error(flowError), zap.Any("flow", flow), zap.Int("flowIndex", idx))
+			stop = stop || !job.KeepRunningOnFailure
+		} else {
+			progresses[idx].Status = v1.Status_Succeed
+		}
+
+		if jobCtx.Err() != nil {
+			stop = true
+		}
+
+		runEntity.Progress.Env = processCtx.Env
+		progresses[idx].FinishTime = time.Now().UnixNano()
+
+		if stop {
+			p.updatePipelineRunEntity(job.Uid, runEntity)
+			continue
+		}
+
+		schedule()
+	}
+
+	// 流水线在流程成功后被取消或超时，调度已停止，未执行的流程不能保持Created状态，否则流水线结果会被判定为成功
+	if err := jobCtx.Err(); err != nil {
+		status, reason := v1.Status_Canceled, "流水线被取消，流程未执行"
+
+		if err == context.DeadlineExceeded {
+			status, reason = v1.Status_TimedOut, fmt.Sprintf("流水线执行超时(%s)，流程未执行", p.timeouts.getPipelineTimeout(job))
+		}
+
+		for idx := range flows {
+			if started[idx] {
+				continue
+			}
+
+			progresses[idx].Status = status
+			progresses[idx].FailReason = reason
+		}
+
+		p.updatePipelineRunEntity(job.Uid, runEntity)
+	}
+}
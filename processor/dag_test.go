@@ -0,0 +1,28 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindCycle(t *testing.T) {
+	cases := []struct {
+		name string
+		deps [][]int
+		want []int
+	}{
+		{name: "empty", deps: [][]int{}, want: nil},
+		{name: "sequential", deps: [][]int{nil, {0}, {1}}, want: nil},
+		{name: "diamond", deps: [][]int{nil, {0}, {0}, {1, 2}}, want: nil},
+		{name: "self", deps: [][]int{{0}}, want: []int{0, 0}},
+		{name: "two", deps: [][]int{{1}, {0}}, want: []int{0, 1, 0}},
+		{name: "tail", deps: [][]int{nil, {0, 3}, {1}, {2}}, want: []int{1, 3, 2, 1}},
+		{name: "after dag", deps: [][]int{nil, {0}, {3}, {2}}, want: []int{2, 3, 2}},
+	}
+
+	for _, c := range cases {
+		if got := findCycle(c.deps); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: findCycle(%v) = %v, want %v", c.name, c.deps, got, c.want)
+		}
+	}
+}
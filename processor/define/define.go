@@ -2,15 +2,11 @@ package define
 
 import (
 	"context"
+	"fmt"
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/processor/logger"
+	"path/filepath"
 	"regexp"
-	"time"
-)
-
-const (
-	FlowTimeout     = 10 * time.Minute
-	PipelineTimeout = 1 * time.Hour
 )
 
 const (
@@ -20,15 +16,44 @@ const (
 	GlobalParamsPipelineStatus = "CI_BUILD_STATUS"
 	// 构建失败原因
 	GlobalParamsPipelineFailReason = "CI_BUILD_FAIL_REASON"
+	// shell流程导出环境变量的文件路径
+	GlobalParamsEnvFile = "CI_ENV_FILE"
+	// svn检出的revision
+	GlobalParamsSvnRevision = "CI_SVN_REVISION"
+	// git检出的commit sha
+	GlobalParamsCommitSha = "CI_COMMIT_SHA"
+	// git检出的commit信息
+	GlobalParamsCommitMessage = "CI_COMMIT_MESSAGE"
+	// git检出的commit作者
+	GlobalParamsCommitAuthor = "CI_COMMIT_AUTHOR"
+	// git检出的分支
+	GlobalParamsBranch = "CI_BRANCH"
+	// 不依赖docker daemon构建时导出的OCI镜像文件的绝对路径，位于工作区之外的输出目录，构建结束后删除；
+	// docker shell流程中输出目录以只读方式挂载，该变量为容器内路径，需保留的文件可复制到工作区后作为构建产物收集
+	GlobalParamsImageTarball = "CI_IMAGE_TARBALL"
 
 	// 构建成功
 	BuildSuccess = "success"
 	// 构建失败
 	BuildFailed = "failed"
+	// 构建被取消
+	BuildCanceled = "canceled"
+	// 构建超时
+	BuildTimedOut = "timeout"
 )
 
+// GetOutputDir 流程产出文件（如导出的镜像文件）的目录，与工作区同级，避免污染后续构建的上下文
+func GetOutputDir(workDir string) string {
+	return filepath.Join(filepath.Dir(workDir), "output")
+}
+
 type ProcessCtx struct {
 	Env map[string]string
+	// 流水线配置的镜像仓库凭证
+	RegistryCredits []*v1.RegistryCredit
+
+	// Fork时的环境变量，用于Merge时找出流程新增或修改的环境变量
+	base map[string]string
 }
 
 var reg *regexp.Regexp
@@ -58,6 +83,54 @@ func (p *ProcessCtx) AppendEnv(env map[string]string) {
 	}
 }
 
+// Fork 复制一份供单个流程使用的上下文，并行执行的流程之间互不影响，流程结束后通过Merge合并其导出的环境变量
+func (p *ProcessCtx) Fork() *ProcessCtx {
+	env := make(map[string]string, len(p.Env))
+	base := make(map[string]string, len(p.Env))
+
+	for k, v := range p.Env {
+		env[k] = v
+		base[k] = v
+	}
+
+	return &ProcessCtx{Env: env, RegistryCredits: p.RegistryCredits, base: base}
+}
+
+// Merge 合并Fork出的上下文中新增或修改的环境变量
+func (p *ProcessCtx) Merge(child *ProcessCtx) {
+	changed := map[string]string{}
+
+	for k, v := range child.Env {
+		if old, ok := child.base[k]; !ok || old != v {
+			changed[k] = v
+		}
+	}
+
+	p.AppendEnv(changed)
+}
+
+// StatusChanged Fork出的上下文中流程是否修改了构建状态（如lua脚本调用fail）
+func (p *ProcessCtx) StatusChanged() bool {
+	for _, k := range []string{GlobalParamsPipelineStatus, GlobalParamsPipelineFailReason} {
+		if p.Env[k] != p.base[k] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RestoreStatus 将构建状态恢复为Fork时的值，流程设置的构建状态只作用于该流程自身，Merge时不传播给其他流程
+func (p *ProcessCtx) RestoreStatus() {
+	for _, k := range []string{GlobalParamsPipelineStatus, GlobalParamsPipelineFailReason} {
+		if v, ok := p.base[k]; ok {
+			p.Env[k] = v
+		} else {
+			delete(p.Env, k)
+		}
+	}
+}
+
 func (p *ProcessCtx) PipelineFailed() bool {
 	if s, ok := p.Env[GlobalParamsPipelineStatus]; ok && s == BuildFailed {
 		return true
@@ -107,6 +180,25 @@ func (p *ProcessCtx) RenderByEnv(input string) (output string) {
 	return
 }
 
+// ExitCodeError 脚本以非0退出码结束
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("shell脚本执行exit code = %d", e.Code)
+}
+
 type FlowRunner interface {
 	Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *ProcessCtx, logger *logger.Logger) error
 }
+
+// 流程执行器可选实现的接口，在流水线提交时校验流程配置
+type Validator interface {
+	Validate(flowCfg *v1.Flow) error
+}
+
+// 流程执行器可选实现的接口，用于清理构建异常中断后遗留的资源（如容器）
+type Cleaner interface {
+	Cleanup(ctx context.Context, buildId string) error
+}
@@ -0,0 +1,296 @@
+package dep_cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/utils"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 缓存volume的标签，记录缓存key，用于淘汰时筛选
+const LabelCacheKey = "trident-ci.cache-key"
+
+// Type 依赖缓存的存储方式
+type Type string
+
+const (
+	// 主机目录
+	TypeDir Type = "dir"
+	// docker命名volume
+	TypeVolume Type = "volume"
+)
+
+// Cache 跨构建的依赖缓存，按key保存在主机目录或docker volume中，挂载到shell流程容器内使用
+// 同一key同时只能被一个流程使用，使用完毕后总大小超出上限时按最近使用时间从旧到新淘汰
+type Cache struct {
+	tp           Type
+	dir          string
+	maxSize      int64
+	dockerClient *client.Client
+	locks        sync.Map
+	usedAt       sync.Map
+	evicting     int32
+}
+
+// maxSize单位为字节，为0时不淘汰
+func NewCache(tp Type, dir string, maxSize int64, dockerClient *client.Client) (*Cache, error) {
+	switch tp {
+	case TypeDir:
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "创建依赖缓存目录[%s]失败", dir)
+		}
+	case TypeVolume:
+	default:
+		return nil, fmt.Errorf("未知的依赖缓存类型: %s", tp)
+	}
+
+	return &Cache{
+		tp:           tp,
+		dir:          dir,
+		maxSize:      maxSize,
+		dockerClient: dockerClient,
+	}, nil
+}
+
+// 缓存key对应的目录名或volume名
+func (c *Cache) getName(key string) string {
+	return fmt.Sprintf("trident-cache-%x", sha256.Sum256([]byte(key)))
+}
+
+// 每个key一个容量为1的channel作为锁，等待时可响应ctx取消
+func (c *Cache) lock(name string) chan struct{} {
+	l, _ := c.locks.LoadOrStore(name, make(chan struct{}, 1))
+	return l.(chan struct{})
+}
+
+// Acquire 锁定key对应的缓存并返回挂载配置（未设置Target），hit表示缓存此前已存在，使用完毕后需调用release释放
+func (c *Cache) Acquire(ctx context.Context, key string) (m mount.Mount, hit bool, release func(), err error) {
+	name := c.getName(key)
+	l := c.lock(name)
+
+	select {
+	case l <- struct{}{}:
+	case <-ctx.Done():
+		return m, false, nil, errors.Wrapf(ctx.Err(), "等待依赖缓存[%s]被中断", key)
+	}
+
+	unlock := func() {
+		c.usedAt.Store(name, time.Now())
+		<-l
+	}
+
+	if c.tp == TypeDir {
+		m, hit, err = c.acquireDir(name)
+	} else {
+		m, hit, err = c.acquireVolume(ctx, name, key)
+	}
+
+	if err != nil {
+		unlock()
+		return m, false, nil, err
+	}
+
+	release = func() {
+		unlock()
+		go c.evict()
+	}
+
+	return m, hit, release, nil
+}
+
+func (c *Cache) acquireDir(name string) (mount.Mount, bool, error) {
+	dir := filepath.Join(c.dir, name)
+	hit := utils.FileExists(dir)
+
+	if !hit {
+		if err := os.Mkdir(dir, 0777); err != nil {
+			return mount.Mount{}, false, errors.Wrapf(err, "创建依赖缓存目录失败")
+		}
+	}
+
+	// 容器内可能以非root用户运行，需要所有用户可写
+	if err := os.Chmod(dir, 0777); err != nil {
+		return mount.Mount{}, false, errors.Wrapf(err, "设置依赖缓存目录权限失败")
+	}
+
+	now := time.Now()
+	os.Chtimes(dir, now, now)
+
+	return mount.Mount{Type: mount.TypeBind, Source: dir}, hit, nil
+}
+
+func (c *Cache) acquireVolume(ctx context.Context, name, key string) (mount.Mount, bool, error) {
+	_, err := c.dockerClient.VolumeInspect(ctx, name)
+	hit := err == nil
+
+	if err != nil {
+		if !client.IsErrNotFound(err) {
+			return mount.Mount{}, false, errors.Wrapf(err, "查询依赖缓存volume失败")
+		}
+
+		_, err = c.dockerClient.VolumeCreate(ctx, volume.VolumeCreateBody{
+			Name:   name,
+			Labels: map[string]string{LabelCacheKey: key},
+		})
+
+		if err != nil {
+			return mount.Mount{}, false, errors.Wrapf(err, "创建依赖缓存volume失败")
+		}
+	}
+
+	return mount.Mount{Type: mount.TypeVolume, Source: name}, hit, nil
+}
+
+type entry struct {
+	name   string
+	size   int64
+	usedAt time.Time
+}
+
+// 总大小超出上限时按最近使用时间从旧到新淘汰
+func (c *Cache) evict() {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return
+	}
+
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	var entries []*entry
+	var err error
+
+	if c.tp == TypeDir {
+		entries, err = c.listDirs()
+	} else {
+		entries, err = c.listVolumes()
+	}
+
+	if err != nil {
+		log.GetLogger().Error("读取依赖缓存失败", zap.Error(err))
+		return
+	}
+
+	var total int64
+
+	for _, e := range entries {
+		total += e.size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].usedAt.Before(entries[j].usedAt)
+	})
+
+	for _, e := range entries {
+		if total <= c.maxSize {
+			return
+		}
+
+		l := c.lock(e.name)
+
+		// 正在使用中的缓存不淘汰
+		select {
+		case l <- struct{}{}:
+		default:
+			continue
+		}
+
+		err := c.remove(e.name)
+		<-l
+
+		if err != nil {
+			log.GetLogger().Error("淘汰依赖缓存失败", zap.Error(err), zap.String("name", e.name))
+			continue
+		}
+
+		c.usedAt.Delete(e.name)
+		total -= e.size
+
+		log.GetLogger().Info("已淘汰依赖缓存", zap.String("name", e.name), zap.Int64("size", e.size))
+	}
+}
+
+func (c *Cache) remove(name string) error {
+	if c.tp == TypeDir {
+		return os.RemoveAll(filepath.Join(c.dir, name))
+	}
+
+	return c.dockerClient.VolumeRemove(context.Background(), name, false)
+}
+
+func (c *Cache) listDirs() ([]*entry, error) {
+	dirs, err := os.ReadDir(c.dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entry
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+
+		info, err := d.Info()
+
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, &entry{
+			name:   d.Name(),
+			size:   utils.DirSize(filepath.Join(c.dir, d.Name())),
+			usedAt: info.ModTime(),
+		})
+	}
+
+	return entries, nil
+}
+
+// docker volume没有最近使用时间，服务重启前未使用过的volume按创建时间排序
+func (c *Cache) listVolumes() ([]*entry, error) {
+	du, err := c.dockerClient.DiskUsage(context.Background())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entry
+
+	for _, v := range du.Volumes {
+		if _, ok := v.Labels[LabelCacheKey]; !ok {
+			continue
+		}
+
+		e := &entry{name: v.Name}
+
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			e.size = v.UsageData.Size
+		}
+
+		if usedAt, ok := c.usedAt.Load(v.Name); ok {
+			e.usedAt = usedAt.(time.Time)
+		} else {
+			e.usedAt, _ = time.Parse(time.RFC3339, v.CreatedAt)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
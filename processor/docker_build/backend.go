@@ -0,0 +1,29 @@
+package docker_build
+
+import (
+	"fmt"
+	"github.com/docker/docker/client"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/registry"
+)
+
+// Backend 镜像构建方式
+type Backend string
+
+const (
+	// 通过docker daemon构建
+	BackendDocker Backend = "docker"
+	// 通过本地buildah命令构建，不依赖docker daemon
+	BackendBuildah Backend = "buildah"
+)
+
+func NewRunnerByBackend(backend Backend, dockerClient *client.Client, registryStore *registry.Store) (define.FlowRunner, error) {
+	switch backend {
+	case BackendDocker:
+		return NewDockerBuildRunner(dockerClient, registryStore), nil
+	case BackendBuildah:
+		return NewBuildahRunner(registryStore), nil
+	default:
+		return nil, fmt.Errorf("未知的镜像构建方式: %s", backend)
+	}
+}
@@ -0,0 +1,238 @@
+package docker_build
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/build_context"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"github.com/skiwer/trident-ci/processor/registry"
+	"github.com/skiwer/trident-ci/processor/utils"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const buildahBin = "buildah"
+
+// BuildahRunner 使用buildah构建镜像，构建结果导出为输出目录中的OCI镜像文件，并可推送到镜像仓库
+type BuildahRunner struct {
+	registry *registry.Store
+}
+
+func NewBuildahRunner(registryStore *registry.Store) *BuildahRunner {
+	return &BuildahRunner{
+		registry: registryStore,
+	}
+}
+
+func (r *BuildahRunner) Validate(flowCfg *v1.Flow) error {
+	return validateCfg(flowCfg)
+}
+
+// 执行buildah命令，输出写入job日志
+func (r *BuildahRunner) exec(ctx context.Context, logger *logger.Logger, args ...string) error {
+	cmd := exec.CommandContext(ctx, buildahBin, args...)
+	cmd.Stdout = logger
+	cmd.Stderr = logger
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrapf(ctx.Err(), "buildah %s被中断", args[0])
+		}
+		return errors.Wrapf(err, "buildah %s执行失败", args[0])
+	}
+
+	return nil
+}
+
+// 按key排序生成k=v形式的命令行参数，保证每次构建参数顺序一致
+func appendKeyValueArgs(args []string, flag string, kvs map[string]string) []string {
+	keys := make([]string, 0, len(kvs))
+
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		args = append(args, flag, k+"="+kvs[k])
+	}
+
+	return args
+}
+
+func (r *BuildahRunner) getBudArgs(cfg *v1.DockerBuildCfg, contextDir, dockerfile, authFile string, tags []string) []string {
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	args := []string{"bud", "--layers", "--file", filepath.Join(contextDir, filepath.FromSlash(dockerfile)), "--authfile", authFile}
+
+	for _, tag := range tags {
+		args = append(args, "--tag", tag)
+	}
+
+	if cfg.Target != "" {
+		args = append(args, "--target", cfg.Target)
+	}
+
+	buildArgs := make(map[string]string, len(cfg.BuildArgs)+1)
+
+	for k, v := range getBuildArgs(cfg) {
+		buildArgs[k] = *v
+	}
+
+	args = appendKeyValueArgs(args, "--build-arg", buildArgs)
+	args = appendKeyValueArgs(args, "--label", cfg.Labels)
+
+	if cfg.NoCache {
+		args = append(args, "--no-cache")
+	}
+
+	if cfg.Pull {
+		args = append(args, "--pull-always")
+	}
+
+	for _, image := range cfg.CacheFrom {
+		args = append(args, "--cache-from", image)
+	}
+
+	return append(args, contextDir)
+}
+
+// OCI镜像文件名，由镜像名转换而来
+func getTarballName(image string) string {
+	return strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(image) + ".oci.tar"
+}
+
+// 将凭证写入临时文件，避免凭证出现在命令行参数中
+func (r *BuildahRunner) writeAuthFile(credits []*v1.RegistryCredit) (string, error) {
+	data, err := registry.AuthFile(credits)
+
+	if err != nil {
+		return "", err
+	}
+
+	file, err := ioutil.TempFile("", "trident-buildah-auth")
+
+	if err != nil {
+		return "", errors.Wrapf(err, "写入镜像仓库凭证失败")
+	}
+
+	_, err = file.Write(data)
+	file.Close()
+
+	if err != nil {
+		os.Remove(file.Name())
+		return "", errors.Wrapf(err, "写入镜像仓库凭证失败")
+	}
+
+	return file.Name(), nil
+}
+
+func (r *BuildahRunner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *define.ProcessCtx, logger *logger.Logger) (err error) {
+	renderCfg(flowCfg, processCtx)
+
+	cfg := flowCfg.DockerBuildCfg
+
+	contextDir := getPathInWorkDir(workDir, cfg.ContextDir)
+
+	if !utils.FileExists(contextDir) {
+		return fmt.Errorf("构建上下文目录[%s]不存在", cfg.ContextDir)
+	}
+
+	dockerfile, err := getDockerfile(workDir, contextDir, cfg)
+
+	if err != nil {
+		return err
+	}
+
+	var modifiers []func(*build_context.BuildContext) error
+
+	if cfg.Dockerfile != "" {
+		modifiers = append(modifiers, build_context.WithDockerfile(cfg.Dockerfile))
+	} else if dockerfile != "" {
+		modifiers = append(modifiers, build_context.WithDockerfilePath(dockerfile))
+	}
+
+	source, err := build_context.New(contextDir, modifiers...)
+
+	if err != nil {
+		return errors.Wrapf(err, "docker build context创建失败")
+	}
+
+	defer source.Close()
+
+	if source.Dockerfile != "" {
+		dockerfile = source.Dockerfile
+	}
+
+	credits, err := r.registry.Resolve(processCtx)
+
+	if err != nil {
+		return err
+	}
+
+	authFile, err := r.writeAuthFile(credits)
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(authFile)
+
+	tags, err := getTags(cfg)
+
+	if err != nil {
+		return err
+	}
+
+	if err = r.exec(ctx, logger, r.getBudArgs(cfg, contextDir, dockerfile, authFile, tags)...); err != nil {
+		return errors.Wrapf(err, "镜像构建失败")
+	}
+
+	// 构建完成后删除本地镜像tag，构建缓存层仍保留在本地存储中
+	defer func() {
+		if rmErr := r.exec(context.Background(), logger, append([]string{"rmi"}, tags...)...); rmErr != nil {
+			logger.Warn("删除本地镜像失败", zap.Error(rmErr))
+		}
+	}()
+
+	outputDir := define.GetOutputDir(workDir)
+
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return errors.Wrapf(err, "创建输出目录失败")
+	}
+
+	tarball := filepath.Join(outputDir, getTarballName(tags[0]))
+
+	logger.Info("导出OCI镜像文件...", zap.String("file", tarball))
+
+	if err = r.exec(ctx, logger, "push", "--authfile", authFile, tags[0], fmt.Sprintf("oci-archive:%s:%s", tarball, tags[0])); err != nil {
+		return errors.Wrapf(err, "导出OCI镜像文件失败")
+	}
+
+	processCtx.Env[define.GlobalParamsImageTarball] = tarball
+
+	if !cfg.PushAfterBuild {
+		return nil
+	}
+
+	for _, tag := range tags {
+		logger.Info("开始推送镜像...", zap.String("image", tag))
+
+		if err = r.exec(ctx, logger, "push", "--authfile", authFile, tag, "docker://"+tag); err != nil {
+			return errors.Wrapf(err, "镜像推送失败")
+		}
+	}
+
+	return nil
+}
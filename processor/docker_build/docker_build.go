@@ -2,6 +2,8 @@ package docker_build
 
 import (
 	"context"
+	"fmt"
+	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
@@ -9,35 +11,170 @@ import (
 	"github.com/skiwer/trident-ci/processor/build_context"
 	"github.com/skiwer/trident-ci/processor/define"
 	"github.com/skiwer/trident-ci/processor/logger"
-	"io"
+	"github.com/skiwer/trident-ci/processor/registry"
+	"github.com/skiwer/trident-ci/processor/utils"
+	"go.uber.org/zap"
+	"path/filepath"
+	"strings"
 )
 
 type Runner struct {
 	dockerClient *client.Client
+	registry     *registry.Store
 }
 
-func NewDockerBuildRunner(dockerClient *client.Client) *Runner {
+func NewDockerBuildRunner(dockerClient *client.Client, registryStore *registry.Store) *Runner {
 	return &Runner{
 		dockerClient: dockerClient,
+		registry:     registryStore,
 	}
 }
 
-func (r *Runner) renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
+// 构建参数中基础镜像对应的参数名
+const baseImageBuildArg = "BASE_IMAGE"
+
+func (r *Runner) Validate(flowCfg *v1.Flow) error {
+	return validateCfg(flowCfg)
+}
+
+func validateCfg(flowCfg *v1.Flow) error {
+	cfg := flowCfg.DockerBuildCfg
+
+	if cfg == nil {
+		return errors.New("docker build流程配置不能为空")
+	}
+
+	if cfg.TargetImage == "" && len(cfg.Tags) == 0 {
+		return errors.New("目标镜像不能为空")
+	}
+
+	if cfg.Dockerfile != "" && cfg.DockerfilePath != "" {
+		return errors.New("Dockerfile内容与Dockerfile路径不能同时指定")
+	}
+
+	return nil
+}
+
+func renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
 	if flowCfg.NoEnvRender {
 		return
 	}
 
-	flowCfg.DockerBuildCfg.BaseImage = processCtx.RenderByEnv(flowCfg.DockerBuildCfg.BaseImage)
-	flowCfg.DockerBuildCfg.TargetImage = processCtx.RenderByEnv(flowCfg.DockerBuildCfg.TargetImage)
-	flowCfg.DockerBuildCfg.Dockerfile = processCtx.RenderByEnv(flowCfg.DockerBuildCfg.Dockerfile)
+	cfg := flowCfg.DockerBuildCfg
+
+	cfg.BaseImage = processCtx.RenderByEnv(cfg.BaseImage)
+	cfg.TargetImage = processCtx.RenderByEnv(cfg.TargetImage)
+	cfg.Dockerfile = processCtx.RenderByEnv(cfg.Dockerfile)
+	cfg.DockerfilePath = processCtx.RenderByEnv(cfg.DockerfilePath)
+	cfg.ContextDir = processCtx.RenderByEnv(cfg.ContextDir)
+	cfg.Target = processCtx.RenderByEnv(cfg.Target)
+
+	for k, v := range cfg.BuildArgs {
+		cfg.BuildArgs[k] = processCtx.RenderByEnv(v)
+	}
+
+	for k, v := range cfg.Labels {
+		cfg.Labels[k] = processCtx.RenderByEnv(v)
+	}
+
+	for i, tag := range cfg.Tags {
+		cfg.Tags[i] = processCtx.RenderByEnv(tag)
+	}
+
+	for i, image := range cfg.CacheFrom {
+		cfg.CacheFrom[i] = processCtx.RenderByEnv(image)
+	}
+}
+
+// 工作区内的路径，不允许通过..访问工作区以外的目录
+func getPathInWorkDir(workDir, path string) string {
+	return filepath.Join(workDir, filepath.Clean("/"+path))
+}
+
+// 获取Dockerfile相对于构建上下文目录的路径，为空时使用docker默认的Dockerfile
+func getDockerfile(workDir, contextDir string, cfg *v1.DockerBuildCfg) (string, error) {
+	if cfg.DockerfilePath == "" {
+		return "", nil
+	}
+
+	dockerfile, err := filepath.Rel(contextDir, getPathInWorkDir(workDir, cfg.DockerfilePath))
+
+	if err != nil || dockerfile == ".." || strings.HasPrefix(dockerfile, "../") {
+		return "", fmt.Errorf("Dockerfile[%s]不在构建上下文目录[%s]内", cfg.DockerfilePath, cfg.ContextDir)
+	}
+
+	return filepath.ToSlash(dockerfile), nil
+}
+
+// 获取渲染后的镜像tag，渲染结果可能为空或不合法，需在构建前校验
+func getTags(cfg *v1.DockerBuildCfg) ([]string, error) {
+	var tags []string
+
+	if cfg.TargetImage != "" {
+		tags = append(tags, cfg.TargetImage)
+	}
+
+	for _, tag := range cfg.Tags {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil, errors.New("目标镜像不能为空")
+	}
+
+	for _, tag := range tags {
+		if _, err := reference.ParseNormalizedNamed(tag); err != nil {
+			return nil, errors.Wrapf(err, "目标镜像[%s]不合法", tag)
+		}
+	}
+
+	return tags, nil
+}
+
+func getBuildArgs(cfg *v1.DockerBuildCfg) map[string]*string {
+	buildArgs := make(map[string]*string, len(cfg.BuildArgs)+1)
+
+	if cfg.BaseImage != "" {
+		baseImage := cfg.BaseImage
+		buildArgs[baseImageBuildArg] = &baseImage
+	}
+
+	for k, v := range cfg.BuildArgs {
+		value := v
+		buildArgs[k] = &value
+	}
+
+	return buildArgs
 }
 
 func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *define.ProcessCtx, logger *logger.Logger) (err error) {
-	r.renderCfg(flowCfg, processCtx)
+	renderCfg(flowCfg, processCtx)
+
+	cfg := flowCfg.DockerBuildCfg
+
+	contextDir := getPathInWorkDir(workDir, cfg.ContextDir)
+
+	if !utils.FileExists(contextDir) {
+		return fmt.Errorf("构建上下文目录[%s]不存在", cfg.ContextDir)
+	}
+
+	dockerfile, err := getDockerfile(workDir, contextDir, cfg)
 
-	dockerfileContent := flowCfg.DockerBuildCfg.Dockerfile
+	if err != nil {
+		return err
+	}
+
+	var modifiers []func(*build_context.BuildContext) error
 
-	source, err := build_context.New(workDir, build_context.WithDockerfile(dockerfileContent))
+	if cfg.Dockerfile != "" {
+		modifiers = append(modifiers, build_context.WithDockerfile(cfg.Dockerfile))
+	} else if dockerfile != "" {
+		modifiers = append(modifiers, build_context.WithDockerfilePath(dockerfile))
+	}
+
+	source, err := build_context.New(contextDir, modifiers...)
 
 	if err != nil {
 		return errors.Wrapf(err, "docker build context创建失败")
@@ -45,20 +182,38 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 
 	defer source.Close()
 
+	if source.Dockerfile != "" {
+		dockerfile = source.Dockerfile
+	}
+
 	buildCtxReader, err := source.AsTarReader()
 
 	if err != nil {
 		return errors.Wrapf(err, "docker build tar reader创建失败")
 	}
 
+	defer buildCtxReader.Close()
+
+	credits, err := r.registry.Resolve(processCtx)
+
+	if err != nil {
+		return err
+	}
+
+	tags, err := getTags(cfg)
+
+	if err != nil {
+		return err
+	}
+
 	resp, err := r.dockerClient.ImageBuild(ctx, buildCtxReader, types.ImageBuildOptions{
-		Tags:           []string{flowCfg.DockerBuildCfg.TargetImage},
+		Tags:           tags,
 		SuppressOutput: false,
 		RemoteContext:  "",
-		NoCache:        false,
+		NoCache:        cfg.NoCache,
 		Remove:         true,
 		ForceRemove:    true,
-		PullParent:     false,
+		PullParent:     cfg.Pull,
 		Isolation:      "",
 		CPUSetCPUs:     "",
 		CPUSetMems:     "",
@@ -70,17 +225,17 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		CgroupParent:   "",
 		NetworkMode:    "",
 		ShmSize:        0,
-		Dockerfile:     "",
+		Dockerfile:     dockerfile,
 		Ulimits:        nil,
-		BuildArgs:      nil,
-		AuthConfigs:    nil,
+		BuildArgs:      getBuildArgs(cfg),
+		AuthConfigs:    registry.AuthConfigs(credits),
 		Context:        nil,
-		Labels:         nil,
+		Labels:         cfg.Labels,
 		Squash:         false,
-		CacheFrom:      nil,
+		CacheFrom:      cfg.CacheFrom,
 		SecurityOpt:    nil,
 		ExtraHosts:     nil,
-		Target:         "",
+		Target:         cfg.Target,
 		SessionID:      "",
 		Platform:       "",
 		Version:        "",
@@ -92,21 +247,41 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		return errors.Wrapf(err, "docker构建请求出错")
 	}
 
-	_, err = io.Copy(logger, resp.Body)
+	logger.Info(fmt.Sprintf("构建上下文大小: %.2fMB", float64(source.Size())/1024/1024))
+
+	err = displayJSONMessages(resp.Body, logger)
 
 	resp.Body.Close()
 
 	if err != nil {
-		return errors.Wrapf(err, "docker build日志io copy失败")
+		return errors.Wrapf(err, "docker镜像构建失败")
 	}
 
-	if !flowCfg.DockerBuildCfg.PushAfterBuild {
+	if !cfg.PushAfterBuild {
 		return nil
 	}
 
-	_, err = r.dockerClient.ImagePush(ctx, flowCfg.DockerBuildCfg.TargetImage, types.ImagePushOptions{
+	for _, tag := range tags {
+		if err := r.pushImage(ctx, tag, credits, logger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) pushImage(ctx context.Context, image string, credits []*v1.RegistryCredit, logger *logger.Logger) error {
+	registryAuth, err := registry.EncodeAuthForImage(credits, image)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Info("开始推送镜像...", zap.String("image", image))
+
+	pushLog, err := r.dockerClient.ImagePush(ctx, image, types.ImagePushOptions{
 		All:           false,
-		RegistryAuth:  "",
+		RegistryAuth:  registryAuth,
 		PrivilegeFunc: nil,
 		Platform:      "",
 	})
@@ -115,5 +290,13 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		return errors.Wrapf(err, "docker image push 出错")
 	}
 
+	err = displayJSONMessages(pushLog, logger)
+
+	pushLog.Close()
+
+	if err != nil {
+		return errors.Wrapf(err, "docker image push 失败")
+	}
+
 	return nil
 }
@@ -0,0 +1,53 @@
+package docker_build
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"io"
+)
+
+// 解析docker build/push返回的json消息流并写入job日志，消息中包含错误时返回该错误
+// 同一镜像层的进度消息只在状态变化时输出一次
+func displayJSONMessages(in io.Reader, logger *logger.Logger) error {
+	dec := json.NewDecoder(in)
+	lastStatus := map[string]string{}
+
+	for {
+		var jm jsonmessage.JSONMessage
+
+		if err := dec.Decode(&jm); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "docker消息解析失败")
+		}
+
+		if jm.Error != nil {
+			return errors.New(jm.Error.Message)
+		}
+
+		if jm.ErrorMessage != "" {
+			return errors.New(jm.ErrorMessage)
+		}
+
+		if jm.Stream != "" {
+			logger.Write([]byte(jm.Stream))
+		}
+
+		if jm.Status != "" {
+			if jm.ID == "" {
+				logger.Info(jm.Status)
+			} else if lastStatus[jm.ID] != jm.Status {
+				lastStatus[jm.ID] = jm.Status
+				logger.Info(fmt.Sprintf("%s: %s", jm.ID, jm.Status))
+			}
+		}
+
+		if jm.Aux != nil {
+			logger.Info(fmt.Sprintf("aux: %s", string(*jm.Aux)))
+		}
+	}
+}
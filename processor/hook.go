@@ -0,0 +1,148 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 流水线结束后执行的一类流程
+type hook struct {
+	name       string
+	flows      []*v1.Flow
+	progresses *[]*v1.FlowProgress
+}
+
+func getHooks(pl *v1.Pipeline, progress *v1.PipelineProgress) []hook {
+	return []hook{
+		{name: "onSuccess", flows: pl.OnSuccess, progresses: &progress.OnSuccessProgresses},
+		{name: "onFailure", flows: pl.OnFailure, progresses: &progress.OnFailureProgresses},
+		{name: "finally", flows: pl.Finally, progresses: &progress.FinallyProgresses},
+	}
+}
+
+func validateHookFlows(pl *v1.Pipeline) error {
+	for _, h := range getHooks(pl, &v1.PipelineProgress{}) {
+		for idx, flow := range h.flows {
+			if len(flow.DependsOn) > 0 {
+				return fmt.Errorf("%s流程[索引=%d]不支持dependsOn", h.name, idx)
+			}
+		}
+	}
+
+	return nil
+}
+
+// 主流程执行结束后，根据执行结果执行onSuccess或onFailure，最后执行finally
+// 主流程被取消时jobCtx已失效，使用服务的ctx执行，期间可再次停止流水线取消执行
+func (p *PipeLineProcessor) runHooks(job *v1.Pipeline, status v1.Status, failReason, jobWorkDir, artifactDir string, processCtx *define.ProcessCtx, jobLogger *logger.Logger, runEntity PipelineRunEntity) {
+	hooks := getHooks(job, runEntity.Progress)
+
+	if len(job.OnSuccess)+len(job.OnFailure)+len(job.Finally) == 0 {
+		return
+	}
+
+	hookCtx, hookCancel := context.WithCancel(p.ctx)
+	defer hookCancel()
+
+	runEntity.CancelFunc = hookCancel
+
+	buildStatus := define.BuildSuccess
+
+	switch status {
+	case v1.Status_Failed:
+		buildStatus = define.BuildFailed
+	case v1.Status_Canceled:
+		buildStatus = define.BuildCanceled
+	case v1.Status_TimedOut:
+		buildStatus = define.BuildTimedOut
+	}
+
+	processCtx.AppendEnv(map[string]string{
+		define.GlobalParamsPipelineStatus:     buildStatus,
+		define.GlobalParamsPipelineFailReason: failReason,
+	})
+
+	for _, h := range hooks {
+		failed := status == v1.Status_Failed || status == v1.Status_Canceled || status == v1.Status_TimedOut
+
+		if h.name == "onSuccess" && failed {
+			continue
+		}
+
+		if h.name == "onFailure" && !failed {
+			continue
+		}
+
+		for _, flow := range h.flows {
+			*h.progresses = append(*h.progresses, &v1.FlowProgress{
+				Flow:   flow,
+				Status: v1.Status_Created,
+			})
+		}
+
+		for idx, flow := range h.flows {
+			progress := (*h.progresses)[idx]
+			progress.StartTime = time.Now().UnixNano()
+
+			if hookCtx.Err() != nil {
+				progress.Status = v1.Status_Canceled
+				progress.FailReason = "流程执行被取消"
+				progress.FinishTime = progress.StartTime
+				continue
+			}
+
+			if !matchConditions(flow, processCtx) {
+				progress.Status = v1.Status_Skipped
+				progress.FinishTime = progress.StartTime
+				continue
+			}
+
+			progress.Status = v1.Status_Running
+			p.updatePipelineRunEntity(job.Uid, runEntity)
+
+			flowLogger := jobLogger.With(zap.String("hook", h.name), zap.Int("flowIndex", idx))
+
+			attempts, err := p.runFlowWithRetry(hookCtx, idx, flow, jobWorkDir, processCtx, flowLogger)
+			progress.Attempts = attempts
+
+			if artifactErr := p.collectArtifacts(jobWorkDir, artifactDir, flow.Artifacts, flowLogger); artifactErr != nil && err == nil {
+				err = artifactErr
+			}
+
+			// 流程执行结果不应改写主流程的执行结果
+			processCtx.AppendEnv(map[string]string{
+				define.GlobalParamsPipelineStatus:     buildStatus,
+				define.GlobalParamsPipelineFailReason: failReason,
+			})
+
+			switch {
+			case err == nil:
+				progress.Status = v1.Status_Succeed
+			case errors.Is(err, context.Canceled):
+				progress.Status = v1.Status_Canceled
+				progress.FailReason = fmt.Sprintf("流程执行被取消: %s", err.Error())
+			case flow.ContinueOnError:
+				progress.Status = v1.Status_FailedAllowed
+				progress.FailReason = err.Error()
+			case errors.As(err, new(*flowTimeoutError)):
+				progress.Status = v1.Status_TimedOut
+				progress.FailReason = err.Error()
+				flowLogger.Error("流水线结束后执行的流程超时", zap.Error(err))
+			default:
+				progress.Status = v1.Status_Failed
+				progress.FailReason = err.Error()
+				flowLogger.Error("流水线结束后执行的流程失败", zap.Error(err))
+			}
+
+			progress.FinishTime = time.Now().UnixNano()
+			runEntity.Progress.Env = processCtx.Env
+			p.updatePipelineRunEntity(job.Uid, runEntity)
+		}
+	}
+}
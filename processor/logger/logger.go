@@ -17,6 +17,11 @@ func NewLogger(pipelineId string, logger *zap.Logger) *Logger {
 	return &Logger{pipelineId: pipelineId, Logger: logger}
 }
 
+// With 创建附加了字段的Logger，并行执行的流程各自使用独立的Logger，避免未换行的内容互相拼接
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{pipelineId: l.pipelineId, Logger: l.Logger.With(fields...)}
+}
+
 // 参考 bufio.ScanLines，若无法以 \n 分割，则以 \r 分割
 //
 // ScanLines is a split function for a Scanner that returns each line of
@@ -65,7 +70,10 @@ func (l *Logger) Write(p []byte) (n int, err error) {
 		lineEnd = true
 	}
 
-	inputReader := bytes.NewReader(p)
+	// 拼接上次写入时未以换行结束的内容
+	inputReader := bytes.NewReader(append([]byte(l.remainingString), p...))
+	l.remainingString = ""
+
 	r := bufio.NewScanner(inputReader)
 
 	r.Split(ScanLines)
@@ -74,14 +82,13 @@ func (l *Logger) Write(p []byte) (n int, err error) {
 
 	for r.Scan() {
 		if lastLine != "" {
-			l.remainingString = ""
-			l.Info(l.remainingString + lastLine)
+			l.Info(lastLine)
 		}
 		lastLine = r.Text()
 	}
 
 	if !lineEnd {
-		l.remainingString += lastLine
+		l.remainingString = lastLine
 	} else {
 		if lastLine != "" {
 			l.Info(lastLine)
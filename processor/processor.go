@@ -10,18 +10,24 @@ import (
 	"github.com/skiwer/trident-ci/processor/logger"
 	"github.com/skiwer/trident-ci/processor/utils"
 	"github.com/skiwer/trident-ci/queue"
+	"github.com/skiwer/trident-ci/store"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"os"
+	"sort"
 	"sync"
 	"time"
 )
 
+const jobDirPrefix = "job-"
+
 type PipeLineProcessor struct {
 	runnerMp   map[v1.FlowType]define.FlowRunner
 	rootPath   string
 	ctx        context.Context
-	progressMp *sync.Map
+	buildStore store.BuildStore
+	cancelMp   *sync.Map
+	timeouts   *Timeouts
 }
 
 type PipelineRunEntity struct {
@@ -30,15 +36,25 @@ type PipelineRunEntity struct {
 	CancelFunc context.CancelFunc
 }
 
-func NewPipelineProcessor(ctx context.Context, rootPath string, runnerMp map[v1.FlowType]define.FlowRunner) *PipeLineProcessor {
+func NewPipelineProcessor(ctx context.Context, rootPath string, runnerMp map[v1.FlowType]define.FlowRunner, buildStore store.BuildStore, timeouts *Timeouts) *PipeLineProcessor {
 	return &PipeLineProcessor{
 		ctx:        ctx,
 		runnerMp:   runnerMp,
 		rootPath:   rootPath,
-		progressMp: &sync.Map{},
+		buildStore: buildStore,
+		cancelMp:   &sync.Map{},
+		timeouts:   timeouts,
 	}
 }
 
+func (p *PipeLineProcessor) getJobRootDir(pipelineId string) string {
+	return fmt.Sprintf("%s/%s%s", p.rootPath, jobDirPrefix, pipelineId)
+}
+
+func (p *PipeLineProcessor) getJobWorkDir(dir string) string {
+	return fmt.Sprintf("%s/workspace", dir)
+}
+
 func (p *PipeLineProcessor) getJobLogFile(dir string) string {
 	return fmt.Sprintf("%s/data/job.log", dir)
 }
@@ -57,20 +73,86 @@ func (p *PipeLineProcessor) InitPipeline(pl *v1.Pipeline) {
 	p.updatePipelineRunEntity(pl.Uid, runEntity)
 }
 
-func (p *PipeLineProcessor) GetPipelineLog(pipelineId string) ([]byte, error) {
-	pl, exists := p.progressMp.Load(pipelineId)
+// ValidatePipeline 在提交时校验流水线配置
+func (p *PipeLineProcessor) ValidatePipeline(pl *v1.Pipeline) error {
+	flows, _, err := getPipelineFlows(pl)
 
-	if !exists {
-		return nil, errors.New("流水线任务不存在")
+	if err != nil {
+		return err
 	}
 
-	entity, ok := pl.(PipelineRunEntity)
+	if err := validateHookFlows(pl); err != nil {
+		return err
+	}
 
-	if !ok {
-		return nil, errors.New("流水线任务数据转换失败")
+	if _, err := resolveTimeout(pl.Timeout, p.timeouts.Pipeline, p.timeouts.MaxPipeline); err != nil {
+		return errors.Wrapf(err, "流水线配置校验失败")
+	}
+
+	if err := validateArtifacts(pl.Artifacts); err != nil {
+		return errors.Wrapf(err, "流水线配置校验失败")
+	}
+
+	flows = append(append(append(flows, pl.OnSuccess...), pl.OnFailure...), pl.Finally...)
+
+	for idx, flow := range flows {
+		runner, ok := p.runnerMp[flow.Type]
+
+		if !ok {
+			return fmt.Errorf("流程[索引=%d]类型[%s]未知", idx, flow.Type.String())
+		}
+
+		if err := validateConditions(flow); err != nil {
+			return errors.Wrapf(err, "流程[索引=%d]配置校验失败", idx)
+		}
+
+		if err := validateRetry(flow.Retry); err != nil {
+			return errors.Wrapf(err, "流程[索引=%d]配置校验失败", idx)
+		}
+
+		if _, err := resolveTimeout(flow.Timeout, p.timeouts.Flow, p.timeouts.MaxFlow); err != nil {
+			return errors.Wrapf(err, "流程[索引=%d]配置校验失败", idx)
+		}
+
+		if err := validateArtifacts(flow.Artifacts); err != nil {
+			return errors.Wrapf(err, "流程[索引=%d]配置校验失败", idx)
+		}
+
+		validator, ok := runner.(define.Validator)
+
+		if !ok {
+			continue
+		}
+
+		if err := validator.Validate(flow); err != nil {
+			return errors.Wrapf(err, "流程[索引=%d]配置校验失败", idx)
+		}
+	}
+
+	return nil
+}
+
+func (p *PipeLineProcessor) getPipelineRecord(pipelineId string) (*store.Record, error) {
+	record, err := p.buildStore.Get(pipelineId)
+
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "流水线任务数据读取失败")
+	}
+
+	return record, nil
+}
+
+func (p *PipeLineProcessor) GetPipelineLog(pipelineId string) ([]byte, error) {
+	record, err := p.getPipelineRecord(pipelineId)
+
+	if err != nil {
+		return nil, err
 	}
 
-	jobLogFile := p.getJobLogFile(entity.JobDir)
+	jobLogFile := p.getJobLogFile(record.JobDir)
 
 	if !utils.FileExists(jobLogFile) {
 		return nil, nil
@@ -80,63 +162,83 @@ func (p *PipeLineProcessor) GetPipelineLog(pipelineId string) ([]byte, error) {
 }
 
 func (p *PipeLineProcessor) GetPipelineProgress(pipelineId string) (progress *v1.PipelineProgress, err error) {
-	pl, exists := p.progressMp.Load(pipelineId)
+	record, err := p.getPipelineRecord(pipelineId)
 
-	if !exists {
-		return nil, errors.New("流水线任务不存在")
+	if err != nil {
+		return nil, err
 	}
 
-	entity, ok := pl.(PipelineRunEntity)
+	return record.Progress, nil
+}
 
-	if !ok {
-		return nil, errors.New("流水线任务数据转换失败")
+// ListPipelineProgress 查询全部流水线任务的进度，按创建时间倒序
+func (p *PipeLineProcessor) ListPipelineProgress() (progresses []*v1.PipelineProgress, err error) {
+	records, err := p.buildStore.List()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "流水线任务数据读取失败")
+	}
+
+	for _, record := range records {
+		progresses = append(progresses, record.Progress)
 	}
 
-	return entity.Progress, nil
+	sort.Slice(progresses, func(i, j int) bool {
+		return progresses[i].CreateTime > progresses[j].CreateTime
+	})
+
+	return progresses, nil
 }
 
 func (p *PipeLineProcessor) StopPipeline(pipelineId string) error {
-	pl, exists := p.progressMp.Load(pipelineId)
-
-	if !exists {
-		return errors.New("流水线任务不存在")
+	if _, err := p.getPipelineRecord(pipelineId); err != nil {
+		return err
 	}
 
-	entity, ok := pl.(PipelineRunEntity)
+	cancel, running := p.cancelMp.Load(pipelineId)
 
-	if !ok {
-		return errors.New("流水线任务数据转换失败")
+	if !running {
+		return errors.New("流水线任务未在运行")
 	}
 
-	entity.CancelFunc()
+	cancel.(context.CancelFunc)()
 
 	return nil
 }
 
 func (p *PipeLineProcessor) DeletePipeline(pipelineId string) error {
-	pl, exists := p.progressMp.Load(pipelineId)
+	record, err := p.getPipelineRecord(pipelineId)
 
-	if !exists {
-		return errors.New("流水线任务不存在")
+	if err != nil {
+		return err
 	}
 
-	defer p.progressMp.Delete(pipelineId)
-
-	entity, ok := pl.(PipelineRunEntity)
-
-	if !ok {
-		return errors.New("流水线任务数据转换失败")
+	if cancel, running := p.cancelMp.Load(pipelineId); running {
+		cancel.(context.CancelFunc)()
 	}
 
-	defer os.RemoveAll(entity.JobDir)
-
-	entity.CancelFunc()
+	if record.JobDir != "" {
+		defer os.RemoveAll(record.JobDir)
+	}
 
-	return nil
+	return p.buildStore.Delete(pipelineId)
 }
 
 func (p *PipeLineProcessor) updatePipelineRunEntity(pipelineId string, entity PipelineRunEntity) {
-	p.progressMp.Store(pipelineId, entity)
+	if entity.CancelFunc != nil {
+		p.cancelMp.Store(pipelineId, entity.CancelFunc)
+	}
+
+	refreshStageProgresses(entity.Progress)
+
+	err := p.buildStore.Save(pipelineId, &store.Record{
+		Progress: withoutCredentials(entity.Progress),
+		JobDir:   entity.JobDir,
+	})
+
+	if err != nil {
+		log.GetLogger().Error("流水线任务数据保存失败", zap.Error(err), zap.String("pipelineId", pipelineId))
+	}
 }
 
 func (p *PipeLineProcessor) Run(ctx context.Context, msg *queue.Message) bool {
@@ -151,21 +253,23 @@ func (p *PipeLineProcessor) Run(ctx context.Context, msg *queue.Message) bool {
 
 	if job.Uid == "" {
 		log.GetLogger().Warn("pipeline uid 不能为空",
-			zap.Any("pipeline", job),
+			zap.String("title", job.Title),
 			zap.String("msgId", msg.ID))
 		return false
 	}
 
-	jobRootDir := fmt.Sprintf("%s/job-%s", p.rootPath, job.Uid)
+	jobRootDir := p.getJobRootDir(job.Uid)
 
-	jobWorkDir := fmt.Sprintf("%s/workspace", jobRootDir)
+	jobWorkDir := p.getJobWorkDir(jobRootDir)
 	jobDataDir := fmt.Sprintf("%s/data", jobRootDir)
 	logFilePath := p.getJobLogFile(jobRootDir)
+	artifactDir := p.getJobArtifactDir(jobRootDir)
 
-	jobCtx, jobCancel := context.WithTimeout(ctx, define.PipelineTimeout)
+	jobCtx, jobCancel := context.WithTimeout(ctx, p.timeouts.getPipelineTimeout(job))
 	defer jobCancel()
+	defer p.cancelMp.Delete(job.Uid)
 
-	processCtx := &define.ProcessCtx{Env: make(map[string]string)}
+	processCtx := &define.ProcessCtx{Env: make(map[string]string), RegistryCredits: job.RegistryCredits}
 	processCtx.AppendEnv(map[string]string{
 		define.GlobalParamsBuildId: job.Uid,
 	})
@@ -191,6 +295,7 @@ func (p *PipeLineProcessor) Run(ctx context.Context, msg *queue.Message) bool {
 	}
 
 	defer os.RemoveAll(jobWorkDir)
+	defer os.RemoveAll(define.GetOutputDir(jobWorkDir))
 
 	if err := os.MkdirAll(jobDataDir, 0755); err != nil {
 		log.GetLogger().Error("创建流水线数据存储路径失败", zap.Error(err), zap.String("path", jobDataDir))
@@ -222,84 +327,78 @@ func (p *PipeLineProcessor) Run(ctx context.Context, msg *queue.Message) bool {
 	runEntity.Progress.Status = v1.Status_Running
 	p.updatePipelineRunEntity(job.Uid, runEntity)
 
-	if len(job.Flows) <= 0 {
+	flows, deps, err := getPipelineFlows(job)
+
+	if err != nil {
+		jobLogger.Error("流程配置不合法", zap.Error(err))
+		runEntity.Progress.Status = v1.Status_Failed
+		runEntity.Progress.FailReason = err.Error()
+		runEntity.Progress.FinishTime = time.Now().UnixNano()
+		p.updatePipelineRunEntity(job.Uid, runEntity)
 		return false
 	}
 
-	for idx, flow := range job.Flows {
-		flowCtx, _ := context.WithTimeout(jobCtx, define.FlowTimeout)
+	runEntity.Progress.StageProgresses = newStageProgresses(job)
 
+	for _, flow := range flows {
 		runEntity.Progress.FlowProgresses = append(runEntity.Progress.FlowProgresses, &v1.FlowProgress{
-			Flow:      flow,
-			Status:    v1.Status_Running,
-			StartTime: time.Now().UnixNano(),
+			Flow:   flow,
+			Status: v1.Status_Created,
 		})
-		runEntity.Progress.Status = v1.Status_Running
-		runEntity.Progress.CurRunningFlowId = flow.Uid
-
-		p.updatePipelineRunEntity(job.Uid, runEntity)
+	}
 
-		err := p.runFlow(flowCtx, idx, flow, jobWorkDir, processCtx, jobLogger)
+	p.runFlows(jobCtx, job, flows, deps, jobWorkDir, artifactDir, processCtx, jobLogger, runEntity)
 
-		breakNow := false
+	status, failReason := getPipelineResult(runEntity.Progress)
 
-		var flowError error
+	if err := p.collectArtifacts(jobWorkDir, artifactDir, job.Artifacts, jobLogger); err != nil {
+		jobLogger.Error("流水线构建产物收集失败", zap.Error(err))
 
-		if err != nil {
-			flowError = err
-		} else {
-			if processCtx.PipelineFailed() {
-				flowError = errors.New(processCtx.GetFailReason())
-			} else if processCtx.PipelineSucceed() {
-				breakNow = true
-			}
+		if status == v1.Status_Succeed || status == v1.Status_SucceedWithWarnings {
+			status, failReason = v1.Status_Failed, err.Error()
 		}
+	}
 
-		if flowError != nil {
-			if errors.Is(flowError, context.Canceled) {
-				runEntity.Progress.FlowProgresses[idx].Status = v1.Status_Canceled
-				runEntity.Progress.FlowProgresses[idx].FailReason = fmt.Sprintf("流程执行被取消: %s", flowError.Error())
-			} else {
-				runEntity.Progress.FlowProgresses[idx].Status = v1.Status_Failed
-				runEntity.Progress.FlowProgresses[idx].FailReason = flowError.Error()
-			}
-			log.GetLogger().Error("流程执行失败", zap.Error(flowError), zap.Any("flow", flow), zap.Int("flowIndex", idx))
-			breakNow = true
-		} else {
-			runEntity.Progress.FlowProgresses[idx].Status = v1.Status_Succeed
-		}
+	p.runHooks(job, status, failReason, jobWorkDir, artifactDir, processCtx, jobLogger, runEntity)
 
-		runEntity.Progress.Env = processCtx.Env
-		runEntity.Progress.FlowProgresses[idx].FinishTime = time.Now().UnixNano()
-		p.updatePipelineRunEntity(job.Uid, runEntity)
+	runEntity.Progress.Status = status
+	runEntity.Progress.FailReason = failReason
+	runEntity.Progress.FinishTime = time.Now().UnixNano()
+	p.updatePipelineRunEntity(job.Uid, runEntity)
 
-		if breakNow {
-			break
-		}
-	}
+	jobLogger.Info("pipeline执行结束", zap.String("title", job.Title))
 
-	for idx, flowProgress := range runEntity.Progress.FlowProgresses {
+	return false
+}
+
+// 根据流程进度得到流水线的执行结果，并行执行时失败的流程之后可能有被取消的流程，优先以失败的流程作为流水线结果，其次为超时的流程
+// 只有允许失败的流程失败时，流水线结果为SucceedWithWarnings
+func getPipelineResult(progress *v1.PipelineProgress) (v1.Status, string) {
+	for idx, flowProgress := range progress.FlowProgresses {
 		if flowProgress.Status == v1.Status_Failed {
-			runEntity.Progress.Status = v1.Status_Failed
-			runEntity.Progress.FailReason = fmt.Sprintf("流程[索引=%d]执行出错: %s", idx, flowProgress.FailReason)
-			break
-		} else if flowProgress.Status == v1.Status_Canceled {
-			runEntity.Progress.Status = v1.Status_Canceled
-			runEntity.Progress.FailReason = fmt.Sprintf("流程[索引=%d]执行被取消", idx)
-			break
+			return v1.Status_Failed, fmt.Sprintf("流程[索引=%d]执行出错: %s", idx, flowProgress.FailReason)
 		}
 	}
 
-	if runEntity.Progress.Status != v1.Status_Canceled && runEntity.Progress.Status != v1.Status_Failed {
-		runEntity.Progress.Status = v1.Status_Succeed
+	for idx, flowProgress := range progress.FlowProgresses {
+		if flowProgress.Status == v1.Status_TimedOut {
+			return v1.Status_TimedOut, fmt.Sprintf("流程[索引=%d]%s", idx, flowProgress.FailReason)
+		}
 	}
 
-	runEntity.Progress.FinishTime = time.Now().UnixNano()
-	p.updatePipelineRunEntity(job.Uid, runEntity)
+	for idx, flowProgress := range progress.FlowProgresses {
+		if flowProgress.Status == v1.Status_Canceled {
+			return v1.Status_Canceled, fmt.Sprintf("流程[索引=%d]执行被取消", idx)
+		}
+	}
 
-	jobLogger.Info("pipeline执行结束", zap.String("title", job.Title))
+	for idx, flowProgress := range progress.FlowProgresses {
+		if flowProgress.Status == v1.Status_FailedAllowed {
+			return v1.Status_SucceedWithWarnings, fmt.Sprintf("允许失败的流程[索引=%d]执行出错: %s", idx, flowProgress.FailReason)
+		}
+	}
 
-	return false
+	return v1.Status_Succeed, ""
 }
 
 func (p *PipeLineProcessor) runFlow(ctx context.Context, flowIndex int, flow *v1.Flow, jobWorkDir string, processCtx *define.ProcessCtx, jobLogger *logger.Logger) (err error) {
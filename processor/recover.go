@@ -0,0 +1,153 @@
+package processor
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/store"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const interruptedFailReason = "服务重启，构建执行被中断"
+
+func isInterrupted(progress *v1.PipelineProgress) bool {
+	if progress == nil {
+		return false
+	}
+
+	switch progress.Status {
+	// 队列为内存实现，服务重启后排队中的构建同样丢失
+	case v1.Status_Created, v1.Status_Started, v1.Status_Running:
+		return true
+	}
+
+	return false
+}
+
+// Recover 在服务启动时对账已持久化的构建记录与工作目录：
+// 服务退出前未执行完毕的构建被标记为失败，requeue为true时则重置后返回以便重新入队（直接填写了凭证的构建除外）；
+// 同时清理这些构建遗留的容器，构建记录持久化时还会清理无对应构建记录的工作目录
+func (p *PipeLineProcessor) Recover(ctx context.Context, requeue bool) (requeued []*v1.Pipeline, err error) {
+	records, err := p.buildStore.List()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "流水线任务数据读取失败")
+	}
+
+	known := map[string]bool{}
+
+	for _, record := range records {
+		if record.Progress == nil || record.Progress.Pipeline == nil {
+			continue
+		}
+
+		pl := record.Progress.Pipeline
+
+		known[pl.Uid] = true
+
+		if !isInterrupted(record.Progress) {
+			continue
+		}
+
+		log.GetLogger().Warn("发现被中断的构建", zap.String("pipelineId", pl.Uid),
+			zap.String("status", record.Progress.Status.String()))
+
+		p.cleanupBuild(ctx, pl.Uid)
+
+		if record.JobDir != "" {
+			jobWorkDir := p.getJobWorkDir(record.JobDir)
+
+			for _, dir := range []string{jobWorkDir, define.GetOutputDir(jobWorkDir)} {
+				if err := os.RemoveAll(dir); err != nil {
+					log.GetLogger().Error("清理被中断构建的工作路径失败", zap.Error(err), zap.String("pipelineId", pl.Uid))
+				}
+			}
+		}
+
+		if requeue && !hasInlineCredentials(pl) {
+			p.InitPipeline(pl)
+			requeued = append(requeued, pl)
+			continue
+		}
+
+		p.markInterrupted(pl.Uid, record)
+	}
+
+	// 非持久化存储在启动时总是为空，无法区分工作目录是否为遗留的，且工作目录可能被其他实例共用
+	if p.buildStore.Persistent() {
+		p.cleanupStaleJobDirs(known)
+	}
+
+	return requeued, nil
+}
+
+func (p *PipeLineProcessor) markInterrupted(pipelineId string, record *store.Record) {
+	now := time.Now().UnixNano()
+
+	for _, flowProgress := range record.Progress.FlowProgresses {
+		if flowProgress.Status == v1.Status_Running {
+			flowProgress.Status = v1.Status_Failed
+			flowProgress.FailReason = interruptedFailReason
+			flowProgress.FinishTime = now
+		}
+	}
+
+	record.Progress.Status = v1.Status_Failed
+	record.Progress.FailReason = interruptedFailReason
+	record.Progress.FinishTime = now
+
+	p.updatePipelineRunEntity(pipelineId, PipelineRunEntity{
+		Progress: record.Progress,
+		JobDir:   record.JobDir,
+	})
+}
+
+func (p *PipeLineProcessor) cleanupBuild(ctx context.Context, pipelineId string) {
+	for flowType, runner := range p.runnerMp {
+		cleaner, ok := runner.(define.Cleaner)
+
+		if !ok {
+			continue
+		}
+
+		if err := cleaner.Cleanup(ctx, pipelineId); err != nil {
+			log.GetLogger().Error("清理被中断构建的遗留资源失败", zap.Error(err),
+				zap.String("pipelineId", pipelineId), zap.String("flowType", flowType.String()))
+		}
+	}
+}
+
+// 删除工作目录下没有对应构建记录的job目录
+func (p *PipeLineProcessor) cleanupStaleJobDirs(known map[string]bool) {
+	entries, err := os.ReadDir(p.rootPath)
+
+	if err != nil {
+		log.GetLogger().Error("读取工作目录失败", zap.Error(err), zap.String("path", p.rootPath))
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), jobDirPrefix) {
+			continue
+		}
+
+		if known[strings.TrimPrefix(entry.Name(), jobDirPrefix)] {
+			continue
+		}
+
+		dir := filepath.Join(p.rootPath, entry.Name())
+
+		if err := os.RemoveAll(dir); err != nil {
+			log.GetLogger().Error("清理过期工作路径失败", zap.Error(err), zap.String("path", dir))
+			continue
+		}
+
+		log.GetLogger().Info("已清理过期工作路径", zap.String("path", dir))
+	}
+}
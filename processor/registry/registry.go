@@ -0,0 +1,180 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"io/ioutil"
+	"strings"
+)
+
+// docker hub在AuthConfigs中使用的地址
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// Store 服务端镜像仓库凭证库，流水线可通过凭证名引用，避免在流水线中明文传递密码
+type Store struct {
+	credits map[string]*v1.RegistryCredit
+}
+
+func NewStore() *Store {
+	return &Store{credits: map[string]*v1.RegistryCredit{}}
+}
+
+// LoadStore 从json文件加载凭证库，格式为{"凭证名": {"server": "", "username": "", "password": ""}}
+func LoadStore(file string) (*Store, error) {
+	s := NewStore()
+
+	if file == "" {
+		return s, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "读取镜像仓库凭证文件[%s]失败", file)
+	}
+
+	if err := json.Unmarshal(data, &s.credits); err != nil {
+		return nil, errors.Wrapf(err, "解析镜像仓库凭证文件[%s]失败", file)
+	}
+
+	for name, credit := range s.credits {
+		credit.Name = name
+	}
+
+	return s, nil
+}
+
+// Resolve 解析流水线的镜像仓库凭证：按名称引用的凭证替换为凭证库中的凭证，其余凭证使用环境变量渲染
+func (s *Store) Resolve(processCtx *define.ProcessCtx) ([]*v1.RegistryCredit, error) {
+	resolved := make([]*v1.RegistryCredit, 0, len(processCtx.RegistryCredits))
+
+	for _, credit := range processCtx.RegistryCredits {
+		if credit.Name == "" {
+			resolved = append(resolved, &v1.RegistryCredit{
+				Server:   processCtx.RenderByEnv(credit.Server),
+				Username: processCtx.RenderByEnv(credit.Username),
+				Password: processCtx.RenderByEnv(credit.Password),
+			})
+			continue
+		}
+
+		stored, ok := s.credits[credit.Name]
+
+		if !ok {
+			return nil, fmt.Errorf("镜像仓库凭证[%s]不存在", credit.Name)
+		}
+
+		resolved = append(resolved, stored)
+	}
+
+	return resolved, nil
+}
+
+// 统一仓库地址格式，去掉协议及路径，docker hub的各种地址统一为docker.io
+func normalizeServer(server string) string {
+	server = strings.TrimPrefix(server, "https://")
+	server = strings.TrimPrefix(server, "http://")
+	server = strings.SplitN(server, "/", 2)[0]
+
+	switch server {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+
+	return server
+}
+
+func toAuthConfig(credit *v1.RegistryCredit) types.AuthConfig {
+	server := normalizeServer(credit.Server)
+
+	if server == "docker.io" {
+		server = dockerHubServer
+	}
+
+	return types.AuthConfig{
+		Username:      credit.Username,
+		Password:      credit.Password,
+		ServerAddress: server,
+	}
+}
+
+// ForImage 查找镜像所在仓库的凭证，找不到时返回nil
+func ForImage(credits []*v1.RegistryCredit, image string) (*v1.RegistryCredit, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "解析镜像名[%s]失败", image)
+	}
+
+	domain := reference.Domain(named)
+
+	for _, credit := range credits {
+		if normalizeServer(credit.Server) == domain {
+			return credit, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// EncodeAuthForImage 生成拉取、推送镜像时使用的RegistryAuth，找不到凭证时返回空字符串
+func EncodeAuthForImage(credits []*v1.RegistryCredit, image string) (string, error) {
+	credit, err := ForImage(credits, image)
+
+	if err != nil || credit == nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(toAuthConfig(credit))
+
+	if err != nil {
+		return "", errors.Wrapf(err, "镜像仓库凭证序列化失败")
+	}
+
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// AuthConfigs 生成镜像构建时拉取基础镜像使用的凭证
+func AuthConfigs(credits []*v1.RegistryCredit) map[string]types.AuthConfig {
+	if len(credits) == 0 {
+		return nil
+	}
+
+	configs := make(map[string]types.AuthConfig, len(credits))
+
+	for _, credit := range credits {
+		authConfig := toAuthConfig(credit)
+		configs[authConfig.ServerAddress] = authConfig
+	}
+
+	return configs
+}
+
+// AuthFile 生成containers-auth.json格式的凭证文件内容，供buildah等不依赖docker daemon的工具使用
+func AuthFile(credits []*v1.RegistryCredit) ([]byte, error) {
+	type auth struct {
+		Auth string `json:"auth"`
+	}
+
+	auths := make(map[string]auth, len(credits))
+
+	for _, credit := range credits {
+		auths[normalizeServer(credit.Server)] = auth{
+			Auth: base64.StdEncoding.EncodeToString([]byte(credit.Username + ":" + credit.Password)),
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"auths": auths})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "镜像仓库凭证序列化失败")
+	}
+
+	return data, nil
+}
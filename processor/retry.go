@@ -0,0 +1,183 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// go-git、docker等返回的错误可能已被转换为字符串，通过错误信息判断是否为网络错误
+var networkErrorMessages = []string{
+	"connection refused",
+	"connection reset",
+	"connection timed out",
+	"no such host",
+	"i/o timeout",
+	"tls handshake timeout",
+	"network is unreachable",
+	"temporary failure in name resolution",
+	"broken pipe",
+	"unexpected eof",
+}
+
+func validateRetry(cfg *v1.RetryCfg) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Retries < 0 {
+		return errors.New("重试次数不能为负数")
+	}
+
+	for _, d := range []string{cfg.Backoff, cfg.MaxBackoff} {
+		if d == "" {
+			continue
+		}
+
+		if _, err := time.ParseDuration(d); err != nil {
+			return errors.Wrapf(err, "重试等待时间[%s]格式错误", d)
+		}
+	}
+
+	return nil
+}
+
+func isNetworkError(err error) bool {
+	// context.DeadlineExceeded同样实现了net.Error，超时及取消不视为网络错误
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || errors.As(err, new(*flowTimeoutError)) {
+		return false
+	}
+
+	var netErr net.Error
+
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, s := range networkErrorMessages {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 判断失败的流程是否可以重试
+func isRetryable(cfg *v1.RetryCfg, err error, timedOut bool) bool {
+	if len(cfg.ErrorClasses) == 0 && len(cfg.ExitCodes) == 0 {
+		return true
+	}
+
+	for _, class := range cfg.ErrorClasses {
+		switch class {
+		case v1.ErrorClass_NetworkError:
+			if isNetworkError(err) {
+				return true
+			}
+		case v1.ErrorClass_TimeoutError:
+			if timedOut {
+				return true
+			}
+		}
+	}
+
+	var exitErr *define.ExitCodeError
+
+	if errors.As(err, &exitErr) {
+		for _, code := range cfg.ExitCodes {
+			if int(code) == exitErr.Code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// 第retry次重试前的等待时间，每次翻倍，不超过maxBackoff
+func getBackoff(cfg *v1.RetryCfg, retry int) time.Duration {
+	backoff, _ := time.ParseDuration(cfg.Backoff)
+	maxBackoff, _ := time.ParseDuration(cfg.MaxBackoff)
+
+	for i := 1; i < retry && backoff > 0; i++ {
+		backoff *= 2
+
+		if maxBackoff > 0 && backoff >= maxBackoff {
+			break
+		}
+	}
+
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
+
+// 执行流程，失败且满足重试策略时等待后重新执行，返回每次执行的记录
+// 每次执行使用独立的上下文，只有最后一次执行导出的环境变量会合并到processCtx
+// 执行器会就地渲染流程配置，每次执行使用配置的副本，避免修改保存在流水线进度中、可能正在被序列化的配置
+func (p *PipeLineProcessor) runFlowWithRetry(ctx context.Context, flowIndex int, flow *v1.Flow, jobWorkDir string, processCtx *define.ProcessCtx, flowLogger *logger.Logger) (attempts []*v1.FlowAttempt, err error) {
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			flowLogger.Info(fmt.Sprintf("开始第%d次重试流程", retry))
+		}
+
+		attemptCtx := processCtx.Fork()
+		attempt := &v1.FlowAttempt{StartTime: time.Now().UnixNano()}
+
+		timeout := p.timeouts.getFlowTimeout(flow)
+		flowCtx, flowCancel := context.WithTimeout(ctx, timeout)
+		err = p.runFlow(flowCtx, flowIndex, proto.Clone(flow).(*v1.Flow), jobWorkDir, attemptCtx, flowLogger)
+		timedOut := flowCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		flowCancel()
+
+		if err != nil && timedOut {
+			err = &flowTimeoutError{timeout: timeout, err: err}
+		}
+
+		attempt.FinishTime = time.Now().UnixNano()
+
+		if err != nil {
+			attempt.FailReason = err.Error()
+		}
+
+		attempts = append(attempts, attempt)
+
+		if err == nil || flow.Retry == nil || retry >= int(flow.Retry.Retries) || ctx.Err() != nil || !isRetryable(flow.Retry, err, timedOut) {
+			processCtx.Merge(attemptCtx)
+			return attempts, err
+		}
+
+		backoff := getBackoff(flow.Retry, retry+1)
+
+		flowLogger.Warn("流程执行失败，等待后重试",
+			zap.String("error", err.Error()),
+			zap.Duration("backoff", backoff),
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			processCtx.Merge(attemptCtx)
+			return attempts, err
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package scm
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/pkg/errors"
+	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/utils"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 镜像仓库拉取全部引用，包括pull request等非分支引用
+const mirrorRefSpec = "+refs/*:refs/*"
+
+// MirrorCache 在主机本地按仓库地址缓存bare镜像仓库，构建时先增量更新镜像，再从镜像clone到job工作区
+type MirrorCache struct {
+	dir      string
+	maxSize  int64
+	maxAge   time.Duration
+	locks    sync.Map
+	evicting int32
+}
+
+// maxSize单位为字节，maxSize或maxAge为0时不按该条件淘汰
+func NewMirrorCache(dir string, maxSize int64, maxAge time.Duration) *MirrorCache {
+	return &MirrorCache{
+		dir:     dir,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+	}
+}
+
+func (c *MirrorCache) getMirrorDir(url string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x.git", sha256.Sum256([]byte(url))))
+}
+
+func (c *MirrorCache) lock(mirrorDir string) *sync.Mutex {
+	l, _ := c.locks.LoadOrStore(mirrorDir, &sync.Mutex{})
+	return l.(*sync.Mutex)
+}
+
+// Acquire 增量更新url对应的镜像仓库并加锁，返回镜像路径，使用完毕后需调用release释放
+func (c *MirrorCache) Acquire(ctx context.Context, url string, auth transport.AuthMethod, logWriter io.Writer) (mirrorDir string, release func(), err error) {
+	mirrorDir = c.getMirrorDir(url)
+
+	l := c.lock(mirrorDir)
+	l.Lock()
+
+	release = func() {
+		l.Unlock()
+		go c.evict()
+	}
+
+	if err = c.update(ctx, mirrorDir, url, auth, logWriter); err != nil {
+		l.Unlock()
+		return "", nil, err
+	}
+
+	now := time.Now()
+	os.Chtimes(mirrorDir, now, now)
+
+	return mirrorDir, release, nil
+}
+
+func (c *MirrorCache) update(ctx context.Context, mirrorDir, url string, auth transport.AuthMethod, logWriter io.Writer) error {
+	var repo *git.Repository
+	var err error
+
+	if utils.FileExists(mirrorDir) {
+		repo, err = git.PlainOpen(mirrorDir)
+	}
+
+	// 镜像不存在或已损坏时重新创建
+	if repo == nil {
+		if err != nil {
+			log.GetLogger().Warn("打开git镜像仓库失败，重新创建", zap.Error(err), zap.String("path", mirrorDir))
+		}
+
+		os.RemoveAll(mirrorDir)
+
+		repo, err = c.create(mirrorDir, url)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName:      git.DefaultRemoteName,
+		Auth:            auth,
+		Progress:        logWriter,
+		Tags:            git.AllTags,
+		Force:           true,
+		InsecureSkipTLS: true,
+	})
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return errors.Wrapf(err, "git镜像仓库更新失败")
+	}
+
+	return c.fixHead(repo)
+}
+
+func (c *MirrorCache) create(mirrorDir, url string) (*git.Repository, error) {
+	repo, err := git.PlainInit(mirrorDir, true)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "git镜像仓库创建失败")
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name:  git.DefaultRemoteName,
+		URLs:  []string{url},
+		Fetch: []config.RefSpec{mirrorRefSpec},
+	})
+
+	if err != nil {
+		os.RemoveAll(mirrorDir)
+		return nil, errors.Wrapf(err, "git镜像仓库创建失败")
+	}
+
+	return repo, nil
+}
+
+// 镜像仓库的HEAD默认指向refs/heads/master，不存在时改为指向main或任一分支，以便不指定分支时可以clone
+func (c *MirrorCache) fixHead(repo *git.Repository) error {
+	if _, err := repo.Head(); err == nil {
+		return nil
+	}
+
+	var target plumbing.ReferenceName
+
+	for _, name := range []string{"main", "master"} {
+		if _, err := repo.Reference(plumbing.NewBranchReferenceName(name), false); err == nil {
+			target = plumbing.NewBranchReferenceName(name)
+			break
+		}
+	}
+
+	if target == "" {
+		branches, err := repo.Branches()
+
+		if err != nil {
+			return errors.Wrapf(err, "获取git镜像仓库分支失败")
+		}
+
+		if ref, err := branches.Next(); err == nil {
+			target = ref.Name()
+		}
+
+		branches.Close()
+	}
+
+	if target == "" {
+		return nil
+	}
+
+	return repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, target))
+}
+
+type mirrorInfo struct {
+	dir     string
+	size    int64
+	usedAt  time.Time
+	expired bool
+}
+
+// 淘汰超过最大闲置时长的镜像，总大小超出上限时按最近使用时间从旧到新淘汰
+func (c *MirrorCache) evict() {
+	if c.maxSize <= 0 && c.maxAge <= 0 {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return
+	}
+
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	entries, err := os.ReadDir(c.dir)
+
+	if err != nil {
+		log.GetLogger().Error("读取git镜像缓存目录失败", zap.Error(err), zap.String("path", c.dir))
+		return
+	}
+
+	var mirrors []*mirrorInfo
+	var total int64
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			continue
+		}
+
+		m := &mirrorInfo{
+			dir:    filepath.Join(c.dir, entry.Name()),
+			usedAt: info.ModTime(),
+		}
+		m.size = utils.DirSize(m.dir)
+		m.expired = c.maxAge > 0 && time.Since(m.usedAt) > c.maxAge
+
+		total += m.size
+		mirrors = append(mirrors, m)
+	}
+
+	sort.Slice(mirrors, func(i, j int) bool {
+		return mirrors[i].usedAt.Before(mirrors[j].usedAt)
+	})
+
+	for _, m := range mirrors {
+		if !m.expired && (c.maxSize <= 0 || total <= c.maxSize) {
+			continue
+		}
+
+		l := c.lock(m.dir)
+		l.Lock()
+
+		// 等待锁期间镜像被使用过，不再淘汰
+		if info, err := os.Stat(m.dir); err != nil || info.ModTime().After(m.usedAt) {
+			l.Unlock()
+			continue
+		}
+
+		err := os.RemoveAll(m.dir)
+		l.Unlock()
+
+		if err != nil {
+			log.GetLogger().Error("淘汰git镜像仓库失败", zap.Error(err), zap.String("path", m.dir))
+			continue
+		}
+
+		total -= m.size
+
+		log.GetLogger().Info("已淘汰git镜像仓库", zap.String("path", m.dir), zap.Int64("size", m.size))
+	}
+}
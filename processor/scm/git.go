@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
@@ -11,11 +12,19 @@ import (
 	"github.com/pkg/errors"
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/define"
 	"go.uber.org/zap"
 	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 )
 
 type GitScm struct {
+	// 本地镜像缓存，为nil时直接从远程仓库clone
+	cache *MirrorCache
 }
 
 func (s *GitScm) getAuth(cfg *v1.ScmCfg) (auth transport.AuthMethod, err error) {
@@ -41,30 +50,334 @@ func (s *GitScm) getAuth(cfg *v1.ScmCfg) (auth transport.AuthMethod, err error)
 	return
 }
 
-func (s *GitScm) Clone(ctx context.Context, workDir string, cfg *v1.ScmCfg, logWriter io.Writer) error {
-	dir := workDir
+// pull request的ref，纯数字时按github的refs/pull/<编号>/head处理
+func (s *GitScm) getPullRequestRef(ref string) plumbing.ReferenceName {
+	if strings.HasPrefix(ref, "refs/") {
+		return plumbing.ReferenceName(ref)
+	}
 
-	auth, err := s.getAuth(cfg)
+	return plumbing.ReferenceName(fmt.Sprintf("refs/pull/%s/head", ref))
+}
 
-	if err != nil {
-		return errors.Wrapf(err, "解析代码拉取凭证失败")
+func (s *GitScm) getSubmoduleRecursivity(cfg *v1.ScmCfg) git.SubmoduleRescursivity {
+	if cfg.DisableSubmodules || len(cfg.SparsePaths) > 0 {
+		return git.NoRecurseSubmodules
 	}
 
-	repo, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+	if cfg.SubmoduleDepth > 0 {
+		return git.SubmoduleRescursivity(cfg.SubmoduleDepth)
+	}
+
+	return git.DefaultSubmoduleRecursionDepth
+}
+
+func (s *GitScm) getCloneOptions(cfg *v1.ScmCfg, auth transport.AuthMethod, logWriter io.Writer) (*git.CloneOptions, error) {
+	opts := &git.CloneOptions{
 		URL:               cfg.Address,
 		Auth:              auth,
-		ReferenceName:     plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", cfg.Branch)),
 		SingleBranch:      true,
-		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		Depth:             int(cfg.Depth),
+		RecurseSubmodules: s.getSubmoduleRecursivity(cfg),
 		Progress:          logWriter,
 		InsecureSkipTLS:   true,
-	})
+	}
+
+	switch cfg.RefType {
+	case v1.RefType_Branch:
+		opts.ReferenceName = plumbing.NewBranchReferenceName(cfg.Branch)
+	case v1.RefType_Tag:
+		if cfg.Ref == "" {
+			return nil, errors.New("tag名不能为空")
+		}
+		opts.ReferenceName = plumbing.NewTagReferenceName(cfg.Ref)
+	case v1.RefType_Commit:
+		if cfg.Ref == "" {
+			return nil, errors.New("commit sha不能为空")
+		}
+	case v1.RefType_PullRequest:
+		if cfg.Ref == "" {
+			return nil, errors.New("pull request编号不能为空")
+		}
+	default:
+		return nil, fmt.Errorf("未知的引用类型[%s]", cfg.RefType.String())
+	}
+
+	// commit及pull request无法直接clone，先拉取分支再检出：
+	// 指定了分支时只拉取该分支，否则拉取全部分支
+	if cfg.RefType == v1.RefType_Commit || cfg.RefType == v1.RefType_PullRequest {
+		if cfg.Branch != "" {
+			opts.ReferenceName = plumbing.NewBranchReferenceName(cfg.Branch)
+		} else {
+			opts.SingleBranch = false
+		}
+		opts.NoCheckout = true
+	}
+
+	if len(cfg.SparsePaths) > 0 {
+		opts.NoCheckout = true
+	}
+
+	return opts, nil
+}
+
+func (s *GitScm) Clone(ctx context.Context, workDir string, cfg *v1.ScmCfg, logWriter io.Writer) (env map[string]string, err error) {
+	dir := workDir
+
+	auth, err := s.getAuth(cfg)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "解析代码拉取凭证失败")
+	}
+
+	opts, err := s.getCloneOptions(cfg, auth, logWriter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 从本地镜像clone时，子模块的相对地址需基于远程仓库地址解析，因此在origin改回远程地址后再更新子模块
+	if s.cache != nil {
+		mirrorDir, release, err := s.cache.Acquire(ctx, cfg.Address, auth, logWriter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer release()
+
+		opts.URL = mirrorDir
+		opts.Auth = nil
+		opts.RecurseSubmodules = git.NoRecurseSubmodules
+	}
+
+	repo, err := git.PlainCloneContext(ctx, dir, false, opts)
 
 	log.GetLogger().Info("git clone result", zap.Any("repo", repo), zap.Error(err))
 
 	if err != nil {
-		return errors.Wrapf(err, "代码拉取失败")
+		return nil, errors.Wrapf(err, "代码拉取失败")
+	}
+
+	var hash plumbing.Hash
+
+	if opts.NoCheckout {
+		if hash, err = s.resolveRef(ctx, repo, cfg, opts.Auth, logWriter); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.cache != nil {
+		if err := s.setOriginUrl(repo, cfg.Address); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.NoCheckout {
+		if len(cfg.SparsePaths) > 0 {
+			err = s.sparseCheckout(ctx, repo, dir, hash, cfg.SparsePaths, logWriter)
+		} else {
+			err = s.checkout(ctx, repo, hash, cfg, auth)
+		}
+	} else if s.cache != nil {
+		err = s.updateSubmodules(ctx, repo, cfg, auth)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.FetchLfs {
+		if err := s.fetchLfs(ctx, dir, cfg, logWriter); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.getCommitEnv(repo, cfg)
+}
+
+// 将origin指向远程仓库地址，以便后续流程中的git命令及子模块使用
+func (s *GitScm) setOriginUrl(repo *git.Repository, url string) error {
+	cfg, err := repo.Config()
+
+	if err != nil {
+		return errors.Wrapf(err, "读取git配置失败")
+	}
+
+	remote, ok := cfg.Remotes[git.DefaultRemoteName]
+
+	if !ok {
+		return fmt.Errorf("git remote[%s]不存在", git.DefaultRemoteName)
+	}
+
+	remote.URLs = []string{url}
+
+	if err := repo.Storer.SetConfig(cfg); err != nil {
+		return errors.Wrapf(err, "写入git配置失败")
+	}
+
+	return nil
+}
+
+// 解析需要检出的commit
+func (s *GitScm) resolveRef(ctx context.Context, repo *git.Repository, cfg *v1.ScmCfg, auth transport.AuthMethod, logWriter io.Writer) (plumbing.Hash, error) {
+	switch cfg.RefType {
+	case v1.RefType_PullRequest:
+		prRef := s.getPullRequestRef(cfg.Ref)
+		localRef := plumbing.ReferenceName(fmt.Sprintf("refs/remotes/%s/%s", git.DefaultRemoteName, strings.TrimPrefix(prRef.String(), "refs/")))
+
+		err := repo.FetchContext(ctx, &git.FetchOptions{
+			RefSpecs:        []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", prRef, localRef))},
+			Depth:           int(cfg.Depth),
+			Auth:            auth,
+			Progress:        logWriter,
+			InsecureSkipTLS: true,
+		})
+
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return plumbing.ZeroHash, errors.Wrapf(err, "拉取pull request[%s]失败", prRef)
+		}
+
+		ref, err := repo.Reference(localRef, true)
+
+		if err != nil {
+			return plumbing.ZeroHash, errors.Wrapf(err, "找不到pull request[%s]", prRef)
+		}
+
+		return ref.Hash(), nil
+	case v1.RefType_Commit:
+		h, err := repo.ResolveRevision(plumbing.Revision(cfg.Ref))
+
+		if err != nil {
+			return plumbing.ZeroHash, errors.Wrapf(err, "找不到commit[%s]", cfg.Ref)
+		}
+
+		return *h, nil
+	default:
+		h, err := repo.ResolveRevision(plumbing.Revision(plumbing.HEAD))
+
+		if err != nil {
+			return plumbing.ZeroHash, errors.Wrapf(err, "获取git HEAD失败")
+		}
+
+		return *h, nil
+	}
+}
+
+func (s *GitScm) checkout(ctx context.Context, repo *git.Repository, hash plumbing.Hash, cfg *v1.ScmCfg, auth transport.AuthMethod) error {
+	w, err := repo.Worktree()
+
+	if err != nil {
+		return errors.Wrapf(err, "获取git工作区失败")
+	}
+
+	if err := w.Checkout(&git.CheckoutOptions{Hash: hash, Force: true}); err != nil {
+		return errors.Wrapf(err, "检出[%s]失败", hash.String())
+	}
+
+	return s.updateSubmodules(ctx, repo, cfg, auth)
+}
+
+func (s *GitScm) updateSubmodules(ctx context.Context, repo *git.Repository, cfg *v1.ScmCfg, auth transport.AuthMethod) error {
+	recursivity := s.getSubmoduleRecursivity(cfg)
+
+	if recursivity == git.NoRecurseSubmodules {
+		return nil
+	}
+
+	w, err := repo.Worktree()
+
+	if err != nil {
+		return errors.Wrapf(err, "获取git工作区失败")
+	}
+
+	subs, err := w.Submodules()
+
+	if err != nil {
+		return errors.Wrapf(err, "获取git子模块失败")
+	}
+
+	err = subs.UpdateContext(ctx, &git.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: recursivity,
+		Auth:              auth,
+	})
+
+	if err != nil {
+		return errors.Wrapf(err, "git子模块更新失败")
 	}
 
 	return nil
 }
+
+// 稀疏检出：HEAD指向检出的commit，使用git命令行的sparse checkout只检出匹配路径下的文件，
+// 未检出的文件在index中标记为skip-worktree，后续流程中git status等命令不会视其为已删除（go-git不支持写入该标记）
+func (s *GitScm) sparseCheckout(ctx context.Context, repo *git.Repository, dir string, hash plumbing.Hash, paths []string, logWriter io.Writer) error {
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, hash)); err != nil {
+		return errors.Wrapf(err, "设置git HEAD失败")
+	}
+
+	cfg, err := repo.Config()
+
+	if err != nil {
+		return errors.Wrapf(err, "读取git配置失败")
+	}
+
+	cfg.Raw.Section("core").SetOption("sparseCheckout", "true")
+
+	if err := repo.Storer.SetConfig(cfg); err != nil {
+		return errors.Wrapf(err, "写入git配置失败")
+	}
+
+	var patterns strings.Builder
+
+	for _, p := range paths {
+		if p = strings.Trim(p, "/"); p != "" {
+			patterns.WriteString("/" + p + "\n")
+		}
+	}
+
+	infoDir := filepath.Join(dir, git.GitDirName, "info")
+
+	if err := os.MkdirAll(infoDir, 0755); err != nil {
+		return errors.Wrapf(err, "写入稀疏检出路径失败")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(infoDir, "sparse-checkout"), []byte(patterns.String()), 0644); err != nil {
+		return errors.Wrapf(err, "写入稀疏检出路径失败")
+	}
+
+	cmd := exec.CommandContext(ctx, gitBin, "read-tree", "-mu", "HEAD")
+	cmd.Dir = dir
+	cmd.Stdout = logWriter
+	cmd.Stderr = logWriter
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrapf(ctx.Err(), "稀疏检出被中断")
+		}
+		return errors.Wrapf(err, "稀疏检出失败")
+	}
+
+	return nil
+}
+
+func (s *GitScm) getCommitEnv(repo *git.Repository, cfg *v1.ScmCfg) (map[string]string, error) {
+	head, err := repo.Head()
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "获取git HEAD失败")
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "获取git commit信息失败")
+	}
+
+	return map[string]string{
+		define.GlobalParamsCommitSha:     commit.Hash.String(),
+		define.GlobalParamsCommitMessage: strings.TrimSpace(commit.Message),
+		define.GlobalParamsCommitAuthor:  commit.Author.Name,
+		define.GlobalParamsBranch:        cfg.Branch,
+	}, nil
+}
@@ -6,19 +6,27 @@ import (
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/processor/define"
 	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
 	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 type Runner struct {
 	rootPath string
+	cache    *MirrorCache
 }
 
 type Cloner interface {
-	Clone(ctx context.Context, workDir string, cfg *v1.ScmCfg, logWriter io.Writer) error
+	// 拉取代码到workDir，返回需要写入流水线环境变量的代码版本信息
+	Clone(ctx context.Context, workDir string, cfg *v1.ScmCfg, logWriter io.Writer) (env map[string]string, err error)
 }
 
-func NewScmRunner() *Runner {
-	return &Runner{}
+// cache为nil时不使用本地镜像缓存
+func NewScmRunner(cache *MirrorCache) *Runner {
+	return &Runner{cache: cache}
 }
 
 func (r *Runner) renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
@@ -28,6 +36,14 @@ func (r *Runner) renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
 
 	flowCfg.ScmCfg.Address = processCtx.RenderByEnv(flowCfg.ScmCfg.Address)
 	flowCfg.ScmCfg.Branch = processCtx.RenderByEnv(flowCfg.ScmCfg.Branch)
+	flowCfg.ScmCfg.Revision = processCtx.RenderByEnv(flowCfg.ScmCfg.Revision)
+	flowCfg.ScmCfg.Ref = processCtx.RenderByEnv(flowCfg.ScmCfg.Ref)
+	flowCfg.ScmCfg.Path = processCtx.RenderByEnv(flowCfg.ScmCfg.Path)
+	flowCfg.ScmCfg.EnvPrefix = processCtx.RenderByEnv(flowCfg.ScmCfg.EnvPrefix)
+
+	for i, p := range flowCfg.ScmCfg.SparsePaths {
+		flowCfg.ScmCfg.SparsePaths[i] = processCtx.RenderByEnv(p)
+	}
 
 	if flowCfg.ScmCfg.Credit != nil {
 		if flowCfg.ScmCfg.Credit.Type != v1.CreditType_NoCredit {
@@ -38,23 +54,105 @@ func (r *Runner) renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
 	}
 }
 
+// 检出路径限制在工作区内
+func (r *Runner) getCloneDir(workDir, path string) string {
+	if path == "" {
+		return workDir
+	}
+
+	return filepath.Join(workDir, filepath.Clean("/"+path))
+}
+
+// 检出目录中已有的文件，目录不存在时返回nil
+func (r *Runner) listEntries(dir string) map[string]bool {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil
+	}
+
+	names := make(map[string]bool, len(entries))
+
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+
+	return names
+}
+
+// 拉取失败时删除本次拉取新增的文件（包括.git目录），以便重试时可以重新检出，不影响检出前已有的文件
+func (r *Runner) resetCloneDir(dir string, existing map[string]bool, logger *logger.Logger) {
+	if existing == nil {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warn("清理检出目录失败", zap.Error(err), zap.String("path", dir))
+		}
+		return
+	}
+
+	for name := range r.listEntries(dir) {
+		if existing[name] {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			logger.Warn("清理检出目录失败", zap.Error(err), zap.String("path", filepath.Join(dir, name)))
+		}
+	}
+}
+
+var envNameReg = regexp.MustCompile(`[^A-Z0-9_]`)
+
+func (r *Runner) getEnvPrefix(cfg *v1.ScmCfg) string {
+	if cfg.EnvPrefix != "" {
+		return cfg.EnvPrefix
+	}
+
+	path := strings.Trim(filepath.ToSlash(filepath.Clean("/"+cfg.Path)), "/")
+
+	if path == "" {
+		return ""
+	}
+
+	return envNameReg.ReplaceAllString(strings.ToUpper(path), "_") + "_"
+}
+
 func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *define.ProcessCtx, logger *logger.Logger) (err error) {
 	var c Cloner
 
 	switch flowCfg.ScmCfg.VcsType {
 	case v1.VCSType_Git:
-		c = &GitScm{}
+		c = &GitScm{cache: r.cache}
+	case v1.VCSType_SVN:
+		c = &SvnScm{}
 	default:
-		return errors.Wrapf(err, "找不到该平台[%s]的代码拉取工具", flowCfg.ScmCfg.VcsType.String())
+		return errors.Errorf("找不到该平台[%s]的代码拉取工具", flowCfg.ScmCfg.VcsType.String())
 	}
 
 	r.renderCfg(flowCfg, processCtx)
 
-	err = c.Clone(ctx, workDir, flowCfg.ScmCfg, logger)
+	cloneDir := r.getCloneDir(workDir, flowCfg.ScmCfg.Path)
+
+	existing := r.listEntries(cloneDir)
+
+	env, err := c.Clone(ctx, cloneDir, flowCfg.ScmCfg, logger)
 
 	if err != nil {
+		r.resetCloneDir(cloneDir, existing, logger)
 		return errors.Wrap(err, "代码拉取失败")
 	}
 
+	processCtx.AppendEnv(env)
+
+	// 不带前缀的变量为最近一次检出的仓库信息，带前缀的变量用于区分多个仓库
+	if prefix := r.getEnvPrefix(flowCfg.ScmCfg); prefix != "" {
+		prefixedEnv := make(map[string]string, len(env))
+
+		for k, v := range env {
+			prefixedEnv[prefix+k] = v
+		}
+
+		processCtx.AppendEnv(prefixedEnv)
+	}
+
 	return nil
 }
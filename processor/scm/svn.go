@@ -1 +1,99 @@
 package scm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/define"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+const svnBin = "svn"
+
+type SvnScm struct {
+}
+
+func (s *SvnScm) getUrl(cfg *v1.ScmCfg) string {
+	url := strings.TrimRight(cfg.Address, "/")
+
+	if cfg.Branch != "" {
+		url = fmt.Sprintf("%s/%s", url, strings.Trim(cfg.Branch, "/"))
+	}
+
+	if cfg.Revision != "" {
+		url = fmt.Sprintf("%s@%s", url, cfg.Revision)
+	}
+
+	return url
+}
+
+func (s *SvnScm) getAuthArgs(cfg *v1.ScmCfg) (args []string, stdin io.Reader, err error) {
+	if cfg.Credit == nil {
+		return
+	}
+
+	switch cfg.Credit.Type {
+	case v1.CreditType_NoCredit:
+	case v1.CreditType_TypeUserPwd:
+		args = []string{"--username", cfg.Credit.Username, "--password-from-stdin"}
+		stdin = strings.NewReader(cfg.Credit.Password)
+	default:
+		err = fmt.Errorf("svn不支持该凭证类型[%s]", cfg.Credit.Type.String())
+	}
+
+	return
+}
+
+func (s *SvnScm) Clone(ctx context.Context, workDir string, cfg *v1.ScmCfg, logWriter io.Writer) (env map[string]string, err error) {
+	authArgs, stdin, err := s.getAuthArgs(cfg)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "解析代码拉取凭证失败")
+	}
+
+	args := []string{
+		"checkout", s.getUrl(cfg), workDir,
+		"--non-interactive",
+		"--no-auth-cache",
+		"--trust-server-cert-failures", "unknown-ca,cn-mismatch,expired,not-yet-valid,other",
+	}
+	args = append(args, authArgs...)
+
+	cmd := exec.CommandContext(ctx, svnBin, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = logWriter
+	cmd.Stderr = logWriter
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, errors.Wrapf(ctx.Err(), "代码拉取被中断")
+		}
+		return nil, errors.Wrapf(err, "代码拉取失败")
+	}
+
+	revision, err := s.getRevision(ctx, workDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{define.GlobalParamsSvnRevision: revision}, nil
+}
+
+func (s *SvnScm) getRevision(ctx context.Context, workDir string) (string, error) {
+	var out, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, svnBin, "info", "--show-item", "revision", "--non-interactive", workDir)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "获取svn revision失败: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
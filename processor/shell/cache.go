@@ -0,0 +1,136 @@
+package shell
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// 计算依赖缓存的key，配置了keyFiles时将匹配文件内容的hash追加到key后
+func getCacheKey(workDir string, cfg *v1.CacheCfg) (string, error) {
+	if len(cfg.KeyFiles) == 0 {
+		return cfg.Key, nil
+	}
+
+	var files []string
+
+	for _, pattern := range cfg.KeyFiles {
+		matches, err := filepath.Glob(filepath.Join(workDir, filepath.Clean("/"+pattern)))
+
+		if err != nil {
+			return "", errors.Wrapf(err, "缓存key文件[%s]格式错误", pattern)
+		}
+
+		files = append(files, matches...)
+	}
+
+	sort.Strings(files)
+
+	h := sha256.New()
+
+	for _, file := range files {
+		rel, _ := filepath.Rel(workDir, file)
+		h.Write([]byte(filepath.ToSlash(rel)))
+
+		f, err := os.Open(file)
+
+		if err != nil {
+			return "", errors.Wrapf(err, "读取缓存key文件[%s]失败", rel)
+		}
+
+		_, err = io.Copy(h, f)
+		f.Close()
+
+		if err != nil {
+			return "", errors.Wrapf(err, "读取缓存key文件[%s]失败", rel)
+		}
+	}
+
+	return fmt.Sprintf("%s-%x", cfg.Key, h.Sum(nil)[:8]), nil
+}
+
+// 锁定流程使用的依赖缓存并生成挂载配置，返回的release用于在流程结束后释放全部缓存
+func (r *Runner) acquireCaches(ctx context.Context, workDir string, cfg *v1.ShellCfg, logger *logger.Logger) (mounts []mount.Mount, release func(), err error) {
+	var releases []func()
+
+	release = func() {
+		for _, f := range releases {
+			f()
+		}
+	}
+
+	if len(cfg.Caches) == 0 {
+		return nil, release, nil
+	}
+
+	if r.cache == nil {
+		return nil, release, errors.New("服务端未启用依赖缓存")
+	}
+
+	keys := make(map[string]string, len(cfg.Caches))
+
+	for _, c := range cfg.Caches {
+		key, err := getCacheKey(workDir, c)
+
+		if err != nil {
+			return nil, release, err
+		}
+
+		keys[c.Path] = key
+	}
+
+	// 锁是按key加的，因此按key的顺序加锁，避免多个流程以不同顺序锁定相同的key时互相等待；多个路径使用相同key时只锁定一次
+	uniqueKeys := make([]string, 0, len(keys))
+	acquired := make(map[string]mount.Mount, len(keys))
+	hits := make(map[string]bool, len(keys))
+
+	for _, key := range keys {
+		if _, ok := hits[key]; !ok {
+			hits[key] = false
+			uniqueKeys = append(uniqueKeys, key)
+		}
+	}
+
+	sort.Strings(uniqueKeys)
+
+	for _, key := range uniqueKeys {
+		m, hit, releaseCache, err := r.cache.Acquire(ctx, key)
+
+		if err != nil {
+			release()
+			return nil, func() {}, err
+		}
+
+		releases = append(releases, releaseCache)
+		acquired[key] = m
+		hits[key] = hit
+	}
+
+	paths := make([]string, 0, len(keys))
+
+	for path := range keys {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		key := keys[path]
+		m := acquired[key]
+		m.Target = path
+		mounts = append(mounts, m)
+
+		logger.Info("使用依赖缓存", zap.String("path", path), zap.String("key", key), zap.Bool("hit", hits[key]))
+	}
+
+	return mounts, release, nil
+}
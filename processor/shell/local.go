@@ -0,0 +1,70 @@
+package shell
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/skiwer/trident-ci/log"
+	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/logger"
+	"go.uber.org/zap"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+const LocalShell = "/bin/bash"
+
+// 主机执行时只继承服务进程的PATH及HOME，避免服务自身的环境变量（如凭证）泄露给构建脚本
+var inheritedEnv = []string{"PATH", "HOME"}
+
+// 在主机上直接执行shell脚本，脚本及其子进程运行在独立的进程组中，ctx结束时整组kill
+func (r *Runner) runLocal(ctx context.Context, workDir string, scriptPath string, env []string, logger *logger.Logger) error {
+	cmd := exec.Command(LocalShell, scriptPath)
+	cmd.Dir = workDir
+	cmd.Env = make([]string, 0, len(inheritedEnv)+len(env))
+
+	for _, key := range inheritedEnv {
+		if v, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, key+"="+v)
+		}
+	}
+
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdout = logger
+	cmd.Stderr = logger
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	logger.Info("开始在主机上执行shell脚本...")
+
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "shell脚本启动失败")
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+				log.GetLogger().Warn("kill shell进程组失败", zap.Error(err), zap.Int("pid", cmd.Process.Pid))
+			}
+		case <-done:
+		}
+	}()
+
+	err := cmd.Wait()
+
+	if ctx.Err() != nil {
+		return errors.Wrap(ctx.Err(), "shell脚本执行被中断")
+	}
+
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return &define.ExitCodeError{Code: exitErr.ExitCode()}
+		}
+		return errors.Wrap(err, "shell脚本执行出错")
+	}
+
+	return nil
+}
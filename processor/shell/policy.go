@@ -0,0 +1,215 @@
+package shell
+
+import (
+	"fmt"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"path/filepath"
+	"strings"
+)
+
+// Policy 服务端配置的docker shell流程容器限制，防止单个构建占满主机资源或越权访问主机
+type Policy struct {
+	// cpu核数上限，为0时不限制
+	MaxCpus float64
+	// 内存上限(MB)，为0时不限制，tmpfs挂载的总大小同样不能超过该上限
+	MaxMemoryMb int64
+	// 流程未指定时使用的cpu核数，为0时使用上限
+	DefaultCpus float64
+	// 流程未指定时使用的内存(MB)，为0时使用上限
+	DefaultMemoryMb int64
+	// 允许使用的网络模式
+	AllowedNetworks []string
+	// 允许挂载的主机路径（含子路径）及docker volume名
+	AllowedVolumes []string
+	// 允许添加的linux capabilities
+	AllowedCaps []string
+	// 允许使用的容器运行用户，未指定用户时使用镜像默认用户
+	AllowedUsers []string
+	// 是否允许添加额外的hosts记录
+	AllowExtraHosts bool
+	// 是否允许特权模式
+	AllowPrivileged bool
+	// 是否允许不使用docker直接在主机上执行shell脚本，主机执行不受以上限制
+	AllowHost bool
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) volumeAllowed(source string) bool {
+	if !strings.HasPrefix(source, "/") {
+		return contains(p.AllowedVolumes, source)
+	}
+
+	source = filepath.Clean(source)
+
+	for _, allowed := range p.AllowedVolumes {
+		if !strings.HasPrefix(allowed, "/") {
+			continue
+		}
+
+		allowed = filepath.Clean(allowed)
+
+		if source == allowed || strings.HasPrefix(source, strings.TrimRight(allowed, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 工作区、输出目录及环境变量文件为内置挂载，不允许被覆盖
+func checkReservedTarget(target string) error {
+	switch filepath.Clean(target) {
+	case WorkDirInContainer, OutputDirInContainer, EnvFileInContainer:
+		return fmt.Errorf("不允许覆盖内置挂载路径[%s]", filepath.Clean(target))
+	}
+	return nil
+}
+
+// Validate 校验shell流程的容器配置是否在允许范围内
+func (p *Policy) Validate(cfg *v1.ShellCfg) error {
+	if cfg.Cpus < 0 || cfg.MemoryMb < 0 {
+		return errors.New("cpu及内存限制不能为负数")
+	}
+
+	if p.MaxCpus > 0 && cfg.Cpus > p.MaxCpus {
+		return fmt.Errorf("cpu核数[%g]超出上限[%g]", cfg.Cpus, p.MaxCpus)
+	}
+
+	if p.MaxMemoryMb > 0 && cfg.MemoryMb > p.MaxMemoryMb {
+		return fmt.Errorf("内存[%dMB]超出上限[%dMB]", cfg.MemoryMb, p.MaxMemoryMb)
+	}
+
+	if cfg.NetworkMode != "" && !contains(p.AllowedNetworks, cfg.NetworkMode) {
+		return fmt.Errorf("不允许使用网络模式[%s]", cfg.NetworkMode)
+	}
+
+	for _, v := range cfg.Volumes {
+		if v.Source == "" || v.Target == "" {
+			return errors.New("挂载的source及target不能为空")
+		}
+
+		if err := checkReservedTarget(v.Target); err != nil {
+			return err
+		}
+
+		if !p.volumeAllowed(v.Source) {
+			return fmt.Errorf("不允许挂载[%s]", v.Source)
+		}
+	}
+
+	for _, c := range cfg.Caches {
+		if !strings.HasPrefix(c.Path, "/") || c.Key == "" {
+			return errors.New("依赖缓存路径需为容器内绝对路径且key不能为空")
+		}
+
+		if err := checkReservedTarget(c.Path); err != nil {
+			return err
+		}
+	}
+
+	if err := p.validateTmpfs(cfg.Tmpfs); err != nil {
+		return err
+	}
+
+	if cfg.User != "" && !contains(p.AllowedUsers, cfg.User) {
+		return fmt.Errorf("不允许使用运行用户[%s]", cfg.User)
+	}
+
+	if len(cfg.ExtraHosts) > 0 && !p.AllowExtraHosts {
+		return errors.New("不允许添加额外的hosts记录")
+	}
+
+	if cfg.Privileged && !p.AllowPrivileged {
+		return errors.New("不允许使用特权模式")
+	}
+
+	for _, c := range cfg.CapAdd {
+		if !contains(p.AllowedCaps, c) {
+			return fmt.Errorf("不允许添加capability[%s]", c)
+		}
+	}
+
+	return nil
+}
+
+// tmpfs占用内存，配置了内存上限时每个tmpfs需指定size，且总大小不能超过上限
+func (p *Policy) validateTmpfs(tmpfs map[string]string) error {
+	var total int64
+
+	for target, options := range tmpfs {
+		if !strings.HasPrefix(target, "/") {
+			return fmt.Errorf("tmpfs挂载路径[%s]需为容器内绝对路径", target)
+		}
+
+		if err := checkReservedTarget(target); err != nil {
+			return err
+		}
+
+		if p.MaxMemoryMb <= 0 {
+			continue
+		}
+
+		var size int64 = -1
+
+		for _, option := range strings.Split(options, ",") {
+			option = strings.TrimSpace(option)
+
+			if !strings.HasPrefix(option, "size=") {
+				continue
+			}
+
+			bytes, err := units.RAMInBytes(strings.TrimPrefix(option, "size="))
+
+			if err != nil || bytes <= 0 {
+				return fmt.Errorf("tmpfs[%s]的%s格式错误", target, option)
+			}
+
+			size = bytes
+		}
+
+		if size < 0 {
+			return fmt.Errorf("服务端限制了内存上限，tmpfs[%s]需指定size", target)
+		}
+
+		total += size
+	}
+
+	if p.MaxMemoryMb > 0 && total > p.MaxMemoryMb*units.MiB {
+		return fmt.Errorf("tmpfs总大小[%dMB]超出内存上限[%dMB]", total/units.MiB, p.MaxMemoryMb)
+	}
+
+	return nil
+}
+
+// 流程未指定资源限制时使用默认值，未配置默认值时使用上限
+func (p *Policy) getResources(cfg *v1.ShellCfg) (cpus float64, memoryMb int64) {
+	cpus, memoryMb = cfg.Cpus, cfg.MemoryMb
+
+	if cpus == 0 {
+		cpus = p.DefaultCpus
+	}
+
+	if cpus == 0 {
+		cpus = p.MaxCpus
+	}
+
+	if memoryMb == 0 {
+		memoryMb = p.DefaultMemoryMb
+	}
+
+	if memoryMb == 0 {
+		memoryMb = p.MaxMemoryMb
+	}
+
+	return
+}
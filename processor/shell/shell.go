@@ -13,27 +13,68 @@ import (
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/log"
 	"github.com/skiwer/trident-ci/processor/define"
+	"github.com/skiwer/trident-ci/processor/dep_cache"
 	"github.com/skiwer/trident-ci/processor/logger"
+	"github.com/skiwer/trident-ci/processor/registry"
 	"github.com/skiwer/trident-ci/processor/utils"
 	"go.uber.org/zap"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
 const WorkDirInContainer = "/code"
 
+// 输出目录（如导出的镜像文件）在容器内的只读挂载路径
+const OutputDirInContainer = "/trident-ci/output"
+
+// 脚本导出环境变量的文件在容器内的路径，宿主机上的文件位于工作区之外，单独以文件方式挂载，容器内无法替换为软链接
+const EnvFileInContainer = "/trident-ci/env"
+
+// 容器标签，记录容器所属的构建id，用于异常中断后清理遗留容器
+const LabelBuildId = "trident-ci.build-id"
+
 type Runner struct {
 	dockerClient *client.Client
+	policy       *Policy
+	registry     *registry.Store
+	cache        *dep_cache.Cache
 }
 
-func NewShellRunner(dockerClient *client.Client) *Runner {
+// cache为nil时不支持依赖缓存
+func NewShellRunner(dockerClient *client.Client, policy *Policy, registryStore *registry.Store, cache *dep_cache.Cache) *Runner {
 	return &Runner{
 		dockerClient: dockerClient,
+		policy:       policy,
+		registry:     registryStore,
+		cache:        cache,
 	}
 }
 
+func (r *Runner) Validate(flowCfg *v1.Flow) error {
+	if flowCfg.ShellCfg == nil {
+		return errors.New("shell流程配置不能为空")
+	}
+
+	if !flowCfg.ShellCfg.WithDocker {
+		if !r.policy.AllowHost {
+			return errors.New("服务端不允许在主机上直接执行shell脚本")
+		}
+		if len(flowCfg.ShellCfg.Caches) > 0 {
+			return errors.New("依赖缓存仅支持docker方式运行")
+		}
+		return nil
+	}
+
+	if len(flowCfg.ShellCfg.Caches) > 0 && r.cache == nil {
+		return errors.New("服务端未启用依赖缓存")
+	}
+
+	return r.policy.Validate(flowCfg.ShellCfg)
+}
+
 func (r *Runner) wrapShellScript(script string) string {
 	template := `#!/bin/bash
 %s
@@ -43,31 +84,96 @@ func (r *Runner) wrapShellScript(script string) string {
 	return ret
 }
 
-//func (r *Runner) readReturnEnvFromFile(file string) map[string]string {
-//	bytes, err := ioutil.ReadFile(file)
-//
-//	env := map[string]string{}
-//
-//	if err != nil {
-//		return env
-//	}
-//
-//	lines := strings.Split(string(bytes), "\n")
-//
-//	for _, line := range lines {
-//		line = strings.Trim(line, " ")
-//		if line == "" {
-//			continue
-//		}
-//		envItem := strings.SplitN(line, "=", 2)
-//		if len(envItem) <= 1 {
-//			continue
-//		}
-//		env[envItem[0]] = envItem[1]
-//	}
-//
-//	return env
-//}
+// 读取脚本通过$CI_ENV_FILE导出的环境变量，每行一个KEY=VALUE，
+// 多行的值使用KEY<<EOF的heredoc形式，以单独一行的分隔符结束
+func (r *Runner) readReturnEnvFromFile(file string) map[string]string {
+	env := map[string]string{}
+
+	bytes, err := readRegularFile(file)
+
+	if err != nil {
+		log.GetLogger().Warn("读取shell导出的环境变量失败", zap.String("file", file), zap.Error(err))
+		return env
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(bytes), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		eqIdx := strings.Index(line, "=")
+		heredocIdx := strings.Index(line, "<<")
+
+		if heredocIdx > 0 && (eqIdx < 0 || heredocIdx < eqIdx) {
+			key := strings.TrimSpace(line[:heredocIdx])
+			delimiter := strings.TrimSpace(line[heredocIdx+2:])
+
+			var valueLines []string
+			closed := false
+
+			for i++; i < len(lines); i++ {
+				if lines[i] == delimiter {
+					closed = true
+					break
+				}
+				valueLines = append(valueLines, lines[i])
+			}
+
+			if !closed {
+				log.GetLogger().Warn("shell导出的环境变量缺少结束分隔符", zap.String("key", key), zap.String("delimiter", delimiter))
+				break
+			}
+
+			env[key] = strings.Join(valueLines, "\n")
+			continue
+		}
+
+		if eqIdx <= 0 {
+			continue
+		}
+
+		env[strings.TrimSpace(line[:eqIdx])] = line[eqIdx+1:]
+	}
+
+	return env
+}
+
+// 脚本可将环境变量文件替换为指向主机其他文件的软链接（如/proc/self/environ），只读取普通文件且不跟随软链接
+func readRegularFile(file string) ([]byte, error) {
+	info, err := os.Lstat(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("[%s]不是普通文件", file)
+	}
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	opened, err := f.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 防止Lstat与Open之间文件被替换
+	if !os.SameFile(info, opened) {
+		return nil, fmt.Errorf("[%s]读取时已被替换", file)
+	}
+
+	return ioutil.ReadAll(f)
+}
 
 func (r *Runner) imageExistsLocal(ctx context.Context, dockerImage string) bool {
 	strList := strings.Split(dockerImage, "/")
@@ -99,7 +205,7 @@ func (r *Runner) imageExistsLocal(ctx context.Context, dockerImage string) bool
 	return false
 }
 
-func (r *Runner) pullImage(ctx context.Context, imagePullPolicy v1.ImagePullPolicy, dockerImage string, logger *logger.Logger) error {
+func (r *Runner) pullImage(ctx context.Context, imagePullPolicy v1.ImagePullPolicy, dockerImage string, processCtx *define.ProcessCtx, logger *logger.Logger) error {
 	pull := false
 
 	switch imagePullPolicy {
@@ -119,9 +225,21 @@ func (r *Runner) pullImage(ctx context.Context, imagePullPolicy v1.ImagePullPoli
 
 	logger.Info("开始拉取镜像...", zap.String("image", dockerImage))
 
+	credits, err := r.registry.Resolve(processCtx)
+
+	if err != nil {
+		return err
+	}
+
+	registryAuth, err := registry.EncodeAuthForImage(credits, dockerImage)
+
+	if err != nil {
+		return err
+	}
+
 	pullLog, err := r.dockerClient.ImagePull(ctx, dockerImage, types.ImagePullOptions{
 		All:           false,
-		RegistryAuth:  "",
+		RegistryAuth:  registryAuth,
 		PrivilegeFunc: nil,
 		Platform:      "",
 	})
@@ -148,6 +266,85 @@ func (r *Runner) renderCfg(flowCfg *v1.Flow, processCtx *define.ProcessCtx) {
 
 	flowCfg.ShellCfg.Cmd = processCtx.RenderByEnv(flowCfg.ShellCfg.Cmd)
 	flowCfg.ShellCfg.DockerImage = processCtx.RenderByEnv(flowCfg.ShellCfg.DockerImage)
+	flowCfg.ShellCfg.User = processCtx.RenderByEnv(flowCfg.ShellCfg.User)
+	flowCfg.ShellCfg.NetworkMode = processCtx.RenderByEnv(flowCfg.ShellCfg.NetworkMode)
+
+	for _, v := range flowCfg.ShellCfg.Volumes {
+		v.Source = processCtx.RenderByEnv(v.Source)
+		v.Target = processCtx.RenderByEnv(v.Target)
+	}
+
+	for i, h := range flowCfg.ShellCfg.ExtraHosts {
+		flowCfg.ShellCfg.ExtraHosts[i] = processCtx.RenderByEnv(h)
+	}
+
+	for _, c := range flowCfg.ShellCfg.Caches {
+		c.Path = processCtx.RenderByEnv(c.Path)
+		c.Key = processCtx.RenderByEnv(c.Key)
+	}
+}
+
+func (r *Runner) getMounts(workDir string, envFile string, cfg *v1.ShellCfg) []mount.Mount {
+	mounts := []mount.Mount{
+		{
+			Type:   mount.TypeBind,
+			Source: workDir,
+			Target: WorkDirInContainer,
+		},
+		{
+			Type:   mount.TypeBind,
+			Source: envFile,
+			Target: EnvFileInContainer,
+		},
+		{
+			Type:     mount.TypeBind,
+			Source:   define.GetOutputDir(workDir),
+			Target:   OutputDirInContainer,
+			ReadOnly: true,
+		},
+	}
+
+	for _, v := range cfg.Volumes {
+		tp := mount.TypeVolume
+
+		if strings.HasPrefix(v.Source, "/") {
+			tp = mount.TypeBind
+		}
+
+		mounts = append(mounts, mount.Mount{
+			Type:     tp,
+			Source:   v.Source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+
+	return mounts
+}
+
+// 输出目录内文件的主机路径替换为容器内的挂载路径
+func getContainerEnv(workDir string, env map[string]string) map[string]string {
+	tarball, ok := env[define.GlobalParamsImageTarball]
+
+	if !ok {
+		return env
+	}
+
+	rel, err := filepath.Rel(define.GetOutputDir(workDir), tarball)
+
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return env
+	}
+
+	ret := make(map[string]string, len(env))
+
+	for k, v := range env {
+		ret[k] = v
+	}
+
+	ret[define.GlobalParamsImageTarball] = filepath.Join(OutputDirInContainer, rel)
+
+	return ret
 }
 
 func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, processCtx *define.ProcessCtx, logger *logger.Logger) error {
@@ -170,24 +367,59 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 
 	defer os.Remove(shellFileAbsolutePath)
 
+	// 环境变量文件放在工作区之外，避免脚本在工作区内将其替换为软链接
+	envFileAbsolutePath := filepath.Join(filepath.Dir(workDir), fmt.Sprintf("env-%s", uid))
+
+	// 容器内可能以非root用户运行，需要所有用户可写
+	err = ioutil.WriteFile(envFileAbsolutePath, nil, 0666)
+
+	if err == nil {
+		err = os.Chmod(envFileAbsolutePath, 0666)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "shell环境变量文件创建失败")
+	}
+
+	defer os.Remove(envFileAbsolutePath)
+
+	defer func() {
+		shellReturnEnv := r.readReturnEnvFromFile(envFileAbsolutePath)
+		processCtx.AppendEnv(shellReturnEnv)
+	}()
+
 	if !flowCfg.ShellCfg.WithDocker {
+		if !r.policy.AllowHost {
+			return errors.New("服务端不允许在主机上直接执行shell脚本")
+		}
+		env := append(utils.ConvertEnvMp2StrSlice(processCtx.Env), fmt.Sprintf("%s=%s", define.GlobalParamsEnvFile, envFileAbsolutePath))
+		return r.runLocal(ctx, workDir, shellFileAbsolutePath, env, logger)
+	}
 
+	// 渲染后的配置需再次校验
+	if err := r.policy.Validate(flowCfg.ShellCfg); err != nil {
+		return errors.Wrap(err, "shell流程容器配置不合法")
 	}
 
 	dockerImage := flowCfg.ShellCfg.DockerImage
 
-	err = r.pullImage(ctx, flowCfg.ShellCfg.ImagePullPolicy, dockerImage, logger)
+	err = r.pullImage(ctx, flowCfg.ShellCfg.ImagePullPolicy, dockerImage, processCtx, logger)
 
 	if err != nil {
 		return err
 	}
 
-	env := utils.ConvertEnvMp2StrSlice(processCtx.Env)
+	// 输出目录不存在时bind挂载会失败
+	if err := os.MkdirAll(define.GetOutputDir(workDir), 0755); err != nil {
+		return errors.Wrap(err, "创建输出目录失败")
+	}
+
+	env := append(utils.ConvertEnvMp2StrSlice(getContainerEnv(workDir, processCtx.Env)), fmt.Sprintf("%s=%s", define.GlobalParamsEnvFile, EnvFileInContainer))
 
 	containerCfg := &container.Config{
 		Hostname:        "",
 		Domainname:      "",
-		User:            "",
+		User:            flowCfg.ShellCfg.User,
 		AttachStdin:     false,
 		AttachStdout:    false,
 		AttachStderr:    false,
@@ -205,41 +437,51 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		NetworkDisabled: false,
 		MacAddress:      "",
 		OnBuild:         nil,
-		Labels:          nil,
+		Labels:          map[string]string{LabelBuildId: processCtx.Env[define.GlobalParamsBuildId]},
 		StopSignal:      "",
 		StopTimeout:     nil,
 		Shell:           nil,
 	}
 
+	cacheMounts, releaseCaches, err := r.acquireCaches(ctx, workDir, flowCfg.ShellCfg, logger)
+
+	if err != nil {
+		return errors.Wrap(err, "依赖缓存获取失败")
+	}
+
+	defer releaseCaches()
+
+	cpus, memoryMb := r.policy.getResources(flowCfg.ShellCfg)
+
 	hostConfig := &container.HostConfig{
 		Binds:           nil,
 		ContainerIDFile: "",
 		LogConfig:       container.LogConfig{},
-		NetworkMode:     "",
+		NetworkMode:     container.NetworkMode(flowCfg.ShellCfg.NetworkMode),
 		PortBindings:    nil,
 		RestartPolicy:   container.RestartPolicy{},
 		AutoRemove:      false,
 		VolumeDriver:    "",
 		VolumesFrom:     nil,
-		CapAdd:          nil,
-		CapDrop:         nil,
+		CapAdd:          flowCfg.ShellCfg.CapAdd,
+		CapDrop:         flowCfg.ShellCfg.CapDrop,
 		CgroupnsMode:    "",
 		DNS:             nil,
 		DNSOptions:      nil,
 		DNSSearch:       nil,
-		ExtraHosts:      nil,
+		ExtraHosts:      flowCfg.ShellCfg.ExtraHosts,
 		GroupAdd:        nil,
 		IpcMode:         "",
 		Cgroup:          "",
 		Links:           nil,
 		OomScoreAdj:     0,
 		PidMode:         "",
-		Privileged:      false,
+		Privileged:      flowCfg.ShellCfg.Privileged,
 		PublishAllPorts: false,
 		ReadonlyRootfs:  false,
 		SecurityOpt:     nil,
 		StorageOpt:      nil,
-		Tmpfs:           nil,
+		Tmpfs:           flowCfg.ShellCfg.Tmpfs,
 		UTSMode:         "",
 		UsernsMode:      "",
 		ShmSize:         0,
@@ -247,14 +489,11 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		Runtime:         "",
 		ConsoleSize:     [2]uint{},
 		Isolation:       "",
-		Resources:       container.Resources{},
-		Mounts: []mount.Mount{
-			{
-				Type:   mount.TypeBind,
-				Source: workDir,
-				Target: WorkDirInContainer,
-			},
+		Resources: container.Resources{
+			NanoCPUs: int64(cpus * 1e9),
+			Memory:   memoryMb * 1024 * 1024,
 		},
+		Mounts:        append(r.getMounts(workDir, envFileAbsolutePath, flowCfg.ShellCfg), cacheMounts...),
 		MaskedPaths:   nil,
 		ReadonlyPaths: nil,
 		Init:          nil,
@@ -301,15 +540,10 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 
 	logger.Info("等待容器运行完毕...")
 
-	//defer func() {
-	//	shellReturnEnv := r.readReturnEnvFromFile(shellEnvFileAbsolutePath)
-	//	processCtx.AppendEnv(shellReturnEnv)
-	//}()
-
 	statusCh, errCh := r.dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
 	select {
 	case <-ctx.Done():
-		return fmt.Errorf("shell脚本执行超时")
+		return errors.Wrap(ctx.Err(), "shell脚本执行被中断")
 	case err := <-errCh:
 		if err != nil {
 			return errors.Wrap(err, "shell脚本执行出错")
@@ -318,7 +552,7 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 		log.GetLogger().Info("ContainerWait status", zap.Any("status", status))
 
 		if status.StatusCode != 0 {
-			return fmt.Errorf("shell脚本执行exit code = %d", status.StatusCode)
+			return &define.ExitCodeError{Code: int(status.StatusCode)}
 		}
 		if status.Error != nil {
 			return fmt.Errorf("等待docker 容器运行完成出错: %s", status.Error.Message)
@@ -327,3 +561,29 @@ func (r *Runner) Run(ctx context.Context, workDir string, flowCfg *v1.Flow, proc
 
 	return nil
 }
+
+func (r *Runner) Cleanup(ctx context.Context, buildId string) error {
+	args := filters.NewArgs()
+	args.Add("label", fmt.Sprintf("%s=%s", LabelBuildId, buildId))
+
+	containers, err := r.dockerClient.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: args,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "查询构建遗留容器失败")
+	}
+
+	for _, c := range containers {
+		err = r.dockerClient.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true})
+
+		if err != nil {
+			return errors.Wrapf(err, "删除构建遗留容器[%s]失败", c.ID)
+		}
+
+		log.GetLogger().Info("已删除构建遗留容器", zap.String("buildId", buildId), zap.String("containerId", c.ID))
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package processor
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+)
+
+// 获取流水线的全部流程及其依赖关系，使用stages时按阶段顺序展开，
+// 每个阶段的流程依赖前一阶段的全部流程，非并行阶段内的流程依赖阶段内的前一个流程
+func getPipelineFlows(pl *v1.Pipeline) ([]*v1.Flow, [][]int, error) {
+	if len(pl.Stages) == 0 {
+		if len(pl.Flows) == 0 {
+			return nil, nil, errors.New("流水线没有流程")
+		}
+
+		deps, err := getFlowDeps(pl.Flows)
+		return pl.Flows, deps, err
+	}
+
+	if len(pl.Flows) > 0 {
+		return nil, nil, errors.New("flows与stages不能同时指定")
+	}
+
+	var flows []*v1.Flow
+	var deps [][]int
+	var prevStage []int
+
+	for stageIdx, stage := range pl.Stages {
+		if len(stage.Flows) == 0 {
+			return nil, nil, fmt.Errorf("阶段[%s]没有流程", getStageName(stageIdx, stage))
+		}
+
+		curStage := make([]int, 0, len(stage.Flows))
+
+		for flowIdx, flow := range stage.Flows {
+			if len(flow.DependsOn) > 0 {
+				return nil, nil, fmt.Errorf("阶段[%s]的流程不支持dependsOn", getStageName(stageIdx, stage))
+			}
+
+			flowDeps := prevStage
+
+			if !stage.Parallel && flowIdx > 0 {
+				flowDeps = []int{len(flows) - 1}
+			}
+
+			curStage = append(curStage, len(flows))
+			flows = append(flows, flow)
+			deps = append(deps, flowDeps)
+		}
+
+		prevStage = curStage
+	}
+
+	return flows, deps, nil
+}
+
+func getStageName(idx int, stage *v1.Stage) string {
+	if stage.Name != "" {
+		return stage.Name
+	}
+	return fmt.Sprintf("索引=%d", idx)
+}
+
+// 初始化阶段进度
+func newStageProgresses(pl *v1.Pipeline) []*v1.StageProgress {
+	var progresses []*v1.StageProgress
+	var flowIdx int32
+
+	for _, stage := range pl.Stages {
+		progress := &v1.StageProgress{
+			Name:   stage.Name,
+			Status: v1.Status_Created,
+		}
+
+		for range stage.Flows {
+			progress.FlowIndexes = append(progress.FlowIndexes, flowIdx)
+			flowIdx++
+		}
+
+		progresses = append(progresses, progress)
+	}
+
+	return progresses
+}
+
+// 根据阶段内流程的进度更新阶段进度
+func refreshStageProgresses(progress *v1.PipelineProgress) {
+	for _, stageProgress := range progress.StageProgresses {
+		var running, started, failedAllowed bool
+		var skipped int
+		var failed, canceled *v1.FlowProgress
+
+		stageProgress.StartTime, stageProgress.FinishTime = 0, 0
+
+		for _, idx := range stageProgress.FlowIndexes {
+			if int(idx) >= len(progress.FlowProgresses) {
+				continue
+			}
+
+			flowProgress := progress.FlowProgresses[idx]
+
+			switch flowProgress.Status {
+			case v1.Status_Created:
+				continue
+			case v1.Status_Skipped:
+				skipped++
+				continue
+			case v1.Status_Running:
+				running = true
+			case v1.Status_FailedAllowed:
+				failedAllowed = true
+			case v1.Status_Failed, v1.Status_TimedOut:
+				if failed == nil {
+					failed = flowProgress
+				}
+			case v1.Status_Canceled:
+				if canceled == nil {
+					canceled = flowProgress
+				}
+			}
+
+			started = true
+
+			if stageProgress.StartTime == 0 || flowProgress.StartTime < stageProgress.StartTime {
+				stageProgress.StartTime = flowProgress.StartTime
+			}
+
+			if flowProgress.FinishTime > stageProgress.FinishTime {
+				stageProgress.FinishTime = flowProgress.FinishTime
+			}
+		}
+
+		stageProgress.FailReason = ""
+
+		switch {
+		case running:
+			stageProgress.Status = v1.Status_Running
+			stageProgress.FinishTime = 0
+		case failed != nil:
+			stageProgress.Status = failed.Status
+			stageProgress.FailReason = failed.FailReason
+		case canceled != nil:
+			stageProgress.Status = v1.Status_Canceled
+			stageProgress.FailReason = canceled.FailReason
+		case failedAllowed:
+			stageProgress.Status = v1.Status_SucceedWithWarnings
+		case started:
+			stageProgress.Status = v1.Status_Succeed
+		case skipped > 0 && skipped == len(stageProgress.FlowIndexes):
+			stageProgress.Status = v1.Status_Skipped
+		default:
+			stageProgress.Status = v1.Status_Created
+		}
+	}
+}
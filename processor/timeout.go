@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+	"time"
+)
+
+// Timeouts 服务端配置的超时时间，流水线及流程未指定时使用默认值，指定的值不能超过上限
+type Timeouts struct {
+	// 流水线主流程默认超时时间
+	Pipeline time.Duration
+	// 流水线超时时间上限，为0时不限制
+	MaxPipeline time.Duration
+	// 流程单次执行默认超时时间
+	Flow time.Duration
+	// 流程超时时间上限，为0时不限制
+	MaxFlow time.Duration
+}
+
+// 流程单次执行超时
+type flowTimeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e *flowTimeoutError) Error() string {
+	return fmt.Sprintf("流程执行超时(%s): %s", e.timeout, e.err.Error())
+}
+
+func (e *flowTimeoutError) Unwrap() error {
+	return e.err
+}
+
+// 解析超时时间，为空时使用默认值，超出上限时返回错误及上限值
+func resolveTimeout(value string, def, max time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+
+	if err != nil {
+		return def, errors.Wrapf(err, "超时时间[%s]格式错误", value)
+	}
+
+	if timeout <= 0 {
+		return def, fmt.Errorf("超时时间[%s]必须大于0", value)
+	}
+
+	if max > 0 && timeout > max {
+		return max, fmt.Errorf("超时时间[%s]超出上限[%s]", value, max)
+	}
+
+	return timeout, nil
+}
+
+// 提交时已校验，执行时配置不合法（如服务端上限调低后重新入队的构建）则使用默认值或上限
+func (t *Timeouts) getPipelineTimeout(pl *v1.Pipeline) time.Duration {
+	timeout, _ := resolveTimeout(pl.Timeout, t.Pipeline, t.MaxPipeline)
+	return timeout
+}
+
+func (t *Timeouts) getFlowTimeout(flow *v1.Flow) time.Duration {
+	timeout, _ := resolveTimeout(flow.Timeout, t.Flow, t.MaxFlow)
+	return timeout
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "os"
+import (
+	"os"
+	"path/filepath"
+)
 
 // 判断所给路径文件/文件夹是否存在
 func FileExists(path string) bool {
@@ -13,3 +16,14 @@ func FileExists(path string) bool {
 	}
 	return true
 }
+
+// 统计文件夹下所有文件的总大小
+func DirSize(path string) (size int64) {
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
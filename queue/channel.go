@@ -35,7 +35,7 @@ func NewChannelQueue(cap int64) *ChannelQueue {
 }
 
 func (q *ChannelQueue) Push(msg *Message) (err error) {
-	log.GetLogger().Info("[channel-queue]msg push", zap.Any("msg", msg))
+	log.GetLogger().Info("[channel-queue]msg push", zap.String("msgId", msg.ID))
 
 	defer func() {
 		if err != nil {
@@ -2,12 +2,21 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	v1 "github.com/skiwer/trident-ci/api/pb/v1"
 	"github.com/skiwer/trident-ci/processor"
 	"github.com/skiwer/trident-ci/queue"
+	"github.com/skiwer/trident-ci/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
 )
 
+// 下载构建产物时每个分片的大小
+const artifactChunkSize = 64 * 1024
+
 type BuildServer struct {
 	processor *processor.PipeLineProcessor
 	queue     queue.Queue
@@ -18,6 +27,14 @@ func NewBuildServer(p *processor.PipeLineProcessor, queue queue.Queue) *BuildSer
 }
 
 func (b *BuildServer) Build(ctx context.Context, in *v1.BuildRequest) (*v1.BuildResponse, error) {
+	if in.Pipeline == nil {
+		return nil, status.Error(codes.InvalidArgument, "流水线不能为空")
+	}
+
+	if err := b.processor.ValidatePipeline(in.Pipeline); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	in.Pipeline.Uid = uuid.NewString()
 	msg := &queue.Message{
 		ID:   in.Pipeline.Uid,
@@ -40,6 +57,15 @@ func (b *BuildServer) GetBuildResult(ctx context.Context, in *v1.GetBuildRequest
 	return &v1.BuildDetail{Progress: progress}, nil
 }
 
+func (b *BuildServer) ListBuilds(ctx context.Context, in *v1.ListBuildsRequest) (*v1.ListBuildsResponse, error) {
+	progresses, err := b.processor.ListPipelineProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListBuildsResponse{Progresses: progresses}, nil
+}
+
 func (b *BuildServer) DeleteBuild(ctx context.Context, in *v1.DeleteBuildRequest) (*v1.EmptyResponse, error) {
 	err := b.processor.DeletePipeline(in.BuildId)
 	if err != nil {
@@ -57,3 +83,48 @@ func (b *BuildServer) StopBuild(ctx context.Context, in *v1.StopBuildRequest) (*
 
 	return &v1.EmptyResponse{}, nil
 }
+
+func (b *BuildServer) ListArtifacts(ctx context.Context, in *v1.ListArtifactsRequest) (*v1.ListArtifactsResponse, error) {
+	artifacts, err := b.processor.ListArtifacts(in.BuildId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+
+	return &v1.ListArtifactsResponse{Artifacts: artifacts}, nil
+}
+
+func (b *BuildServer) DownloadArtifact(in *v1.DownloadArtifactRequest, stream v1.Build_DownloadArtifactServer) error {
+	file, err := b.processor.GetArtifactFile(in.BuildId, in.Path)
+	if err != nil {
+		if errors.Is(err, processor.ErrArtifactNotFound) || errors.Is(err, store.ErrNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := make([]byte, artifactChunkSize)
+
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&v1.ArtifactChunk{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
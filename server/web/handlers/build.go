@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/skiwer/trident-ci/processor"
 	"github.com/skiwer/trident-ci/queue"
 	"github.com/skiwer/trident-ci/server/web/models"
 	"github.com/skiwer/trident-ci/server/web/utils"
+	"github.com/skiwer/trident-ci/store"
 	"net/http"
+	"path/filepath"
 )
 
 type BuildHandler struct {
@@ -29,6 +32,11 @@ func (h *BuildHandler) Build(c *gin.Context) {
 		return
 	}
 
+	if err := h.processor.ValidatePipeline(p.Pipeline); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildResp(err.Error(), utils.PipelineValidateFailed, nil))
+		return
+	}
+
 	id := uuid.NewString()
 	p.Pipeline.Uid = id
 
@@ -81,6 +89,17 @@ func (h *BuildHandler) GetBuildProgress(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.BuildResp("流水线任务执行进度查询成功", utils.Success, progress))
 }
 
+func (h *BuildHandler) ListBuilds(c *gin.Context) {
+	progresses, err := h.processor.ListPipelineProgress()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildResp(err.Error(), utils.PipelineBuildJobListFailed, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildResp("流水线任务列表查询成功", utils.Success, progresses))
+}
+
 func (h *BuildHandler) StopBuild(c *gin.Context) {
 	p := new(models.PipelineBuildIdBind)
 
@@ -114,3 +133,49 @@ func (h *BuildHandler) DeleteBuild(c *gin.Context) {
 
 	c.JSON(http.StatusOK, utils.BuildResp("流水线任务删除成功", utils.Success, nil))
 }
+
+func (h *BuildHandler) ListArtifacts(c *gin.Context) {
+	p := new(models.PipelineBuildIdBind)
+
+	if !p.Validate(c) {
+		return
+	}
+
+	artifacts, err := h.processor.ListArtifacts(p.Id)
+
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, utils.BuildResp(err.Error(), utils.PipelineArtifactListFailed, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildResp("流水线构建产物查询成功", utils.Success, artifacts))
+}
+
+func (h *BuildHandler) DownloadArtifact(c *gin.Context) {
+	p := new(models.PipelineArtifactBind)
+
+	if !p.Validate(c) {
+		return
+	}
+
+	file, err := h.processor.GetArtifactFile(p.Id, p.Path)
+
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		if errors.Is(err, processor.ErrArtifactNotFound) || errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, utils.BuildResp(err.Error(), utils.PipelineArtifactDownloadFailed, nil))
+		return
+	}
+
+	c.FileAttachment(file, filepath.Base(file))
+}
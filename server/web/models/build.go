@@ -38,3 +38,21 @@ func (p *PipelineBuildIdBind) Validate(c *gin.Context) (success bool) {
 
 	return true
 }
+
+type PipelineArtifactBind struct {
+	PipelineBuildIdBind
+	Path string `form:"path" binding:"required"`
+}
+
+func (p *PipelineArtifactBind) Validate(c *gin.Context) (success bool) {
+	if !p.PipelineBuildIdBind.Validate(c) {
+		return false
+	}
+
+	if err := c.ShouldBindQuery(p); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildResp(err.Error(), utils.ParamBindError, nil))
+		return false
+	}
+
+	return true
+}
@@ -17,10 +17,13 @@ func NewBuildRecordRouter(p *processor.PipeLineProcessor, queue queue.Queue) Rou
 	serverHandler := handlers.NewBuildHandler(p, queue)
 	routerList := []RouterItem{
 		{http.MethodPost, "", serverHandler.Build},
+		{http.MethodGet, "", serverHandler.ListBuilds},
 		{http.MethodGet, "/:id/progress", serverHandler.GetBuildProgress},
 		{http.MethodGet, "/:id/log", serverHandler.GetBuildLog},
 		{http.MethodPost, "/:id/stop", serverHandler.StopBuild},
 		{http.MethodDelete, "/:id", serverHandler.DeleteBuild},
+		{http.MethodGet, "/:id/artifacts", serverHandler.ListArtifacts},
+		{http.MethodGet, "/:id/artifacts/download", serverHandler.DownloadArtifact},
 	}
 	return &pipelineBuildRouter{"build", routerList}
 }
@@ -36,8 +36,9 @@ func (s *Server) Start(ctx context.Context, port int) (err error) {
 	go func() {
 		select {
 		case <-ctx.Done():
-			shutDownCtx, _ := context.WithTimeout(context.Background(), 2*time.Second)
+			shutDownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			httpSvr.Shutdown(shutDownCtx)
+			cancel()
 		}
 	}()
 
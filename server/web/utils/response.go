@@ -16,4 +16,8 @@ const (
 	PipelineBuildJobProgressGetFailed = 30006
 	PipelineBuildJobStopFailed        = 30007
 	PipelineBuildJobDeleteFailed      = 30008
+	PipelineValidateFailed            = 30009
+	PipelineArtifactListFailed        = 30010
+	PipelineArtifactDownloadFailed    = 30011
+	PipelineBuildJobListFailed        = 30012
 )
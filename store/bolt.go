@@ -0,0 +1,111 @@
+package store
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var buildBucket = []byte("builds")
+
+// 基于BoltDB的文件存储，构建记录在进程重启后依然可查
+type BoltStore struct {
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrapf(err, "创建构建记录存储目录失败")
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 3 * time.Second})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "打开构建记录存储文件[%s]失败", path)
+	}
+
+	// 构建记录包含流水线参数等敏感信息，已存在的文件同样限制为仅属主可读写
+	if err := os.Chmod(path, 0600); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "设置构建记录存储文件[%s]权限失败", path)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(buildBucket)
+		return err
+	})
+
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "初始化构建记录存储失败")
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(buildId string, record *Record) error {
+	data, err := json.Marshal(record)
+
+	if err != nil {
+		return errors.Wrapf(err, "构建记录序列化失败")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(buildBucket).Put([]byte(buildId), data)
+	})
+}
+
+func (s *BoltStore) Get(buildId string) (record *Record, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(buildBucket).Get([]byte(buildId))
+
+		if data == nil {
+			return ErrNotFound
+		}
+
+		record = &Record{}
+
+		return json.Unmarshal(data, record)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (s *BoltStore) Delete(buildId string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(buildBucket).Delete([]byte(buildId))
+	})
+}
+
+func (s *BoltStore) List() (records []*Record, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(buildBucket).ForEach(func(k, v []byte) error {
+			record := &Record{}
+
+			if err := json.Unmarshal(v, record); err != nil {
+				return errors.Wrapf(err, "构建记录[%s]反序列化失败", string(k))
+			}
+
+			records = append(records, record)
+
+			return nil
+		})
+	})
+
+	return
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) Persistent() bool {
+	return true
+}
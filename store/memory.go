@@ -0,0 +1,51 @@
+package store
+
+import (
+	"sync"
+)
+
+// 内存存储，进程重启后构建记录丢失
+type MemoryStore struct {
+	mp *sync.Map
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mp: &sync.Map{}}
+}
+
+func (s *MemoryStore) Save(buildId string, record *Record) error {
+	s.mp.Store(buildId, record)
+	return nil
+}
+
+func (s *MemoryStore) Get(buildId string) (*Record, error) {
+	v, exists := s.mp.Load(buildId)
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return v.(*Record), nil
+}
+
+func (s *MemoryStore) Delete(buildId string) error {
+	s.mp.Delete(buildId)
+	return nil
+}
+
+func (s *MemoryStore) List() (records []*Record, err error) {
+	s.mp.Range(func(key, value interface{}) bool {
+		records = append(records, value.(*Record))
+		return true
+	})
+
+	return
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) Persistent() bool {
+	return false
+}
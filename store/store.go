@@ -0,0 +1,43 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	v1 "github.com/skiwer/trident-ci/api/pb/v1"
+)
+
+var ErrNotFound = errors.New("流水线任务不存在")
+
+// 构建记录，持久化保存流水线进度及其工作目录
+type Record struct {
+	Progress *v1.PipelineProgress `json:"progress"`
+	JobDir   string               `json:"jobDir"`
+}
+
+type BuildStore interface {
+	Save(buildId string, record *Record) error
+	Get(buildId string) (*Record, error)
+	Delete(buildId string) error
+	List() ([]*Record, error)
+	Close() error
+	// Persistent 构建记录在进程重启后是否仍然存在
+	Persistent() bool
+}
+
+type Type string
+
+const (
+	TypeMemory Type = "memory"
+	TypeBolt   Type = "bolt"
+)
+
+func NewStoreByType(tp Type, path string) (s BuildStore, err error) {
+	switch tp {
+	case TypeMemory:
+		return NewMemoryStore(), nil
+	case TypeBolt:
+		return NewBoltStore(path)
+	default:
+		return nil, fmt.Errorf("未知的构建记录存储类型: %s", tp)
+	}
+}